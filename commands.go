@@ -1,21 +1,40 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/pbojar/dictextract/internal/database"
 	"github.com/pbojar/dictextract/internal/dawg"
+	"github.com/pbojar/dictextract/internal/extract"
+	"github.com/pbojar/dictextract/internal/gaddag"
+	"github.com/pbojar/dictextract/internal/grid"
+	"github.com/pbojar/dictextract/internal/hunspell"
 	"github.com/pbojar/dictextract/internal/wiktionary"
+	"github.com/pbojar/dictextract/internal/wordlist"
+	"github.com/pbojar/dictextract/internal/wordnet"
 )
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(s *state, args ...string) error
+	callback    func(ctx context.Context, s *state, args ...string) error
 }
 
 func makeCommands() map[string]cliCommand {
@@ -36,21 +55,85 @@ func makeCommands() map[string]cliCommand {
 			callback:    commandListDAWGs,
 		},
 		"makeDB": {
-			name:        "makeDB <rawFileName>",
-			description: "Makes a DB from words and definitions extracted from <rawFileName>.",
-			callback:    commandMakeDB,
+			name: "makeDB [-source <source>] [-lang <codes>] [-workers <n>] [-resume] [-license <license>] [-dumpDate <date>] <rawFileName>",
+			description: `Makes a DB from words and definitions extracted from <rawFileName>, read as the <source> format:
+    wiktionary (default) for a gzipped Wiktextract JSONL dump, wordnet for a WordNet in the WN-LMF XML
+    format, hunspell for a Hunspell .dic file (with the .aff file of the same name beside it) whose words
+    are expanded into all their affixed forms, wordlist for a word list with one word per line, or tsv for
+    a dictionary with word, pos, gloss and tags columns (see the wordlist package). WordNets, word lists
+    and .tsv files may be gzipped (.gz). Only words in the languages with the comma separated Wiktionary
+    codes <codes> (default en) are extracted; the hunspell, wordlist and tsv sources take exactly one
+    code. Codes other than en, fr, es, de and pl need letters in "alphabets" in the filter config. Words
+    are filtered with the filter config at filter_config_path in the user config, or with the default word
+    policy if it is not set. Words without definitions from Hunspell dictionaries, word lists and .tsv
+    files are kept whatever "require_definition" is set to in the config. Entries are decoded and filtered
+    by <n> workers (default one per CPU). Each import is recorded and checkpointed with every batch
+    written; -resume continues the last, interrupted import of <rawFileName> from its checkpoint, provided
+    the file has not changed. Ctrl-C stops an import after committing the batch in progress and prints how
+    to resume it. Imports record the file's name, <license> (default that stated in the file, as by
+    WordNets, or else that of the source, e.g. CC BY-SA 4.0 for Wiktionary), dump <date> (default any
+    YYYYMMDD date in the file name) and the filter config, and are linked to the words they keep and the
+    definitions they add.`,
+			callback: commandMakeDB,
 		},
 		"makeDAWG": {
-			name: "makeDAWG <minWordLen> <maxWordLen> <saveFileName>",
+			name: "makeDAWG [-from <file>] [-lang <code>] [-excludeTags <tags>] [-inflections=false] [-sounds] <minWordLen> <maxWordLen> <saveFileName>",
 			description: `Makes a DAWG from words with lengths between <minWordLen> and <maxWordLen> (inclusive) 
-    found in the current database. Saves the DAWG as a .gob file in the configured save directory.`,
+    found in the current database in the language <code> (default en). Words whose definitions are all
+    tagged with one of the comma separated <tags> (e.g., archaic,obsolete,misspelling) are left out, while
+    words with any other definition are kept. Words that are only inflected forms of other words are left
+    out if -inflections=false is given. Saves the DAWG as a .gob file in the configured save directory.
+    With -sounds, the IPA, rhymes and syllables of each word are also saved to a .sounds.json file holding
+    an array indexed by the DAWG's word index. With -from, the words are read instead from the word list
+    <file> (one word per line, optionally gzipped), or stdin if <file> is -, without needing a database;
+    they are lowercased, sorted and deduplicated, lines that are not words of letters only are skipped,
+    and no other flag may be given.`,
 			callback: commandMakeDAWG,
 		},
+		"makeGADDAG": {
+			name: "makeGADDAG [-from <file>] [-lang <code>] [-excludeTags <tags>] [-inflections=false] <minWordLen> <maxWordLen> <saveFileName>",
+			description: `Makes a GADDAG from words with lengths between <minWordLen> and <maxWordLen> (inclusive)
+    found in the current database or the word list <file>, selected as for makeDAWG. Saves the GADDAG as a
+    .gob file in the configured save directory.`,
+			callback: commandMakeGADDAG,
+		},
+		"define": {
+			name: "define [-lang <code>] <word>",
+			description: `Lists every definition of <word> in the language <code> (default en) found in the current database,
+    followed by the definitions of any words <word> is an inflected form of.`,
+			callback: commandDefine,
+		},
+		"thesaurus": {
+			name: "thesaurus [-lang <code>] <word>",
+			description: `Lists the synonyms, hypernyms, antonyms and other related words of each sense of <word> in the
+    language <code> (default en), as imported from a WordNet into the current database.`,
+			callback: commandThesaurus,
+		},
+		"provenance": {
+			name: "provenance [-lang <code>] <word>",
+			description: `Lists the imports that kept <word> in the language <code> (default en) in the current database,
+    noting which added the word or its definitions, with the file, dump date, license and filter config of each.`,
+			callback: commandProvenance,
+		},
+		"rhymes": {
+			name: "rhymes [-lang <code>] [-rhyme <rhyme>] [-syllables <n>] [word]",
+			description: `Lists the words in the language <code> (default en) found in the current database that rhyme
+    with [word], or that have the rhyme <rhyme> (e.g., -rhyme=-ʌn). With -syllables, only words with <n>
+    syllables are listed, and [word] and <rhyme> may both be left out to list every word with <n> syllables.`,
+			callback: commandRhymes,
+		},
+		"solveGrid": {
+			name: "solveGrid <dawgFileName> <minWordLen> <row>...",
+			description: `Lists every word in the saved DAWG <dawgFileName> with at least <minWordLen> letters that can be
+    traced through adjacent cells of the grid given by the <row> args. Each rune of a row is a cell,
+    unless the row contains commas, in which case cells are comma separated (e.g., qu,a,r,t).`,
+			callback: commandSolveGrid,
+		},
 	}
 	return commands
 }
 
-func commandHelp(s *state, args ...string) error {
+func commandHelp(ctx context.Context, s *state, args ...string) error {
 	fmt.Println(`dictextract is a command line tool to build databases of words and definitions and 
 Directed Acyclic Word Graphs (DAWGs) from open source dictionaries (e.g., wiktionary) for use in word games.`)
 	fmt.Print("\nUsage:\n")
@@ -61,7 +144,7 @@ Directed Acyclic Word Graphs (DAWGs) from open source dictionaries (e.g., wiktio
 	return nil
 }
 
-func commandListDAWGs(s *state, args ...string) error {
+func commandListDAWGs(ctx context.Context, s *state, args ...string) error {
 	dawgDir := *s.cfg.DAWGSaveDirPath
 	files, err := os.ReadDir(dawgDir)
 	if err != nil {
@@ -78,7 +161,7 @@ func commandListDAWGs(s *state, args ...string) error {
 	return nil
 }
 
-func commandListRaws(s *state, args ...string) error {
+func commandListRaws(ctx context.Context, s *state, args ...string) error {
 	rawDir := *s.cfg.RawDictDirPath
 	files, err := os.ReadDir(rawDir)
 	if err != nil {
@@ -95,71 +178,497 @@ func commandListRaws(s *state, args ...string) error {
 	return nil
 }
 
-func commandMakeDB(s *state, args ...string) error {
-	gzFilepath := args[0]
-	err := wiktionary.ExtractToDB(gzFilepath, s.db)
+// newFlagSet creates a flag.FlagSet for the optional args of the command name.
+// Flags must be given before the command's positional args.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stdout)
+	return fs
+}
+
+func commandMakeDB(ctx context.Context, s *state, args ...string) error {
+	fs := newFlagSet("makeDB")
+	sourceName := fs.String("source", "wiktionary", "format of the raw file: wiktionary, wordnet, hunspell, wordlist or tsv")
+	langCodes := fs.String("lang", "en", "comma separated Wiktionary language codes of the words to extract")
+	workers := fs.Int("workers", runtime.NumCPU(), "number of workers decoding and filtering entries")
+	resume := fs.Bool("resume", false, "resume the last, interrupted import of the raw file")
+	license := fs.String("license", "", "license of the raw file, recorded with the import")
+	dumpDate := fs.String("dumpDate", "", "date of the raw file as YYYY-MM-DD, recorded with the import")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	args = fs.Args()
+
+	// Check for proper number of args
+	if len(args) != 1 {
+		return fmt.Errorf("error: expected 1 argument, '%d' given", len(args))
+	}
+	if err := s.requireDB(); err != nil {
+		return err
+	}
+
+	// Use the configured filter config, if any, whose alphabets may add
+	// languages
+	filterCfg := extract.DefaultFilterConfig()
+	if s.cfg.FilterConfigPath != nil {
+		var err error
+		filterCfg, err = extract.LoadFilterConfig(*s.cfg.FilterConfigPath)
+		if err != nil {
+			return fmt.Errorf("error loading filter config '%s': %v", *s.cfg.FilterConfigPath, err)
+		}
+	}
+
+	langs := []extract.Language{}
+	for _, code := range splitList(*langCodes) {
+		lang, err := extract.LookupLanguage(code, filterCfg.Alphabets)
+		if err != nil {
+			return fmt.Errorf("error: %v", err)
+		}
+		langs = append(langs, lang)
+	}
+	if len(langs) == 0 {
+		return fmt.Errorf("error: at least one language code must be given")
+	}
+
+	src, err := newSource(*sourceName, args[0], langs)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	run, err := startImportRun(ctx, s, importRun{
+		source:    *sourceName,
+		path:      args[0],
+		langs:     langs,
+		filterCfg: filterCfg,
+		license:   *license,
+		dumpDate:  *dumpDate,
+	}, src, *resume)
+	if err != nil {
+		return err
+	}
+
+	err = extract.ToDB(ctx, src, langs, filterCfg, s.conn, *workers, run)
+	if errors.Is(err, context.Canceled) {
+		return importInterrupted(*sourceName, langs, args[0])
+	}
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func commandMakeDAWG(s *state, args ...string) error {
+// importInterrupted returns the error of an import of the file at path being
+// interrupted, after printing the command resuming it. Imports from stdin are
+// recorded but cannot be resumed.
+func importInterrupted(sourceName string, langs []extract.Language, path string) error {
+	if path == "-" {
+		return fmt.Errorf("error: makeDB interrupted, imports from stdin cannot be resumed")
+	}
+	codes := []string{}
+	for _, lang := range langs {
+		codes = append(codes, lang.Code)
+	}
+	fmt.Printf("Resume the import with: makeDB -source %s -lang %s -resume '%s'\n", sourceName,
+		strings.Join(codes, ","), path)
+	return fmt.Errorf("error: makeDB interrupted")
+}
 
-	// Check for proper number of args
-	if len(args) != 3 {
-		return fmt.Errorf("error: expected 3 arguments, '%d' given", len(args))
+// newSource opens the raw file at path as the source format name. Sources whose
+// files do not record the language of their words require exactly one of langs.
+func newSource(name, path string, langs []extract.Language) (extract.Source, error) {
+	if name != "wiktionary" && name != "wordnet" && len(langs) != 1 {
+		return nil, fmt.Errorf("error: the %s source takes exactly one language code, '%d' given", name, len(langs))
 	}
+	switch name {
+	case "wiktionary":
+		return wiktionary.NewSource(path)
+	case "wordnet":
+		return wordnet.NewSource(path)
+	case "hunspell":
+		return hunspell.NewSource(path, langs[0].Code)
+	case "wordlist":
+		return wordlist.NewSource(path, langs[0].Code)
+	case "tsv":
+		return wordlist.NewTSVSource(path, langs[0].Code)
+	}
+	return nil, fmt.Errorf("error: unknown source '%s'", name)
+}
 
-	// Ensure first two args can be converted to integers
-	minLenStr := args[0]
-	maxLenStr := args[1]
-	minLen, err := strconv.Atoi(minLenStr)
+// importRun describes an import of a raw file by makeDB.
+type importRun struct {
+	source    string // Name of the source format
+	path      string
+	langs     []extract.Language
+	filterCfg extract.FilterConfig
+	license   string // License of the file, replacing the source's if set
+	dumpDate  string // Date of the dump as YYYY-MM-DD, found in the file name if not set
+}
+
+// dumpDatePattern matches dates in dump file names, e.g. "20240501" in
+// "enwiktionary-20240501-pages-articles.xml".
+var dumpDatePattern = regexp.MustCompile(`(?:^|[^0-9])(\d{4})-?(\d{2})-?(\d{2})(?:[^0-9]|$)`)
+
+// startImportRun records import r of the raw file read by src in the
+// "import_runs" table, with the license and dump date of the file and the filter
+// config its words are kept by. The license is that of the file if src is an
+// extract.LicensedSource, or else that of the source format, such as
+// Wiktionary's. With resume, it instead finds the last import of the file, which
+// must be unfinished and have read the same file, unchanged, with the same
+// format, languages and filter config. The files of an
+// extract.MultiFileSource, such as a Hunspell affix file, must be unchanged too.
+// Imports from stdin cannot be resumed.
+func startImportRun(ctx context.Context, s *state, r importRun, src extract.Source, resume bool) (extract.ImportRun, error) {
+	filterCfg, err := json.Marshal(r.filterCfg)
 	if err != nil {
-		return fmt.Errorf("error: '%s' is not convertable to an integer", minLenStr)
+		return extract.ImportRun{}, fmt.Errorf("error marshalling filter config: %v", err)
+	}
+	codes := []string{}
+	for _, lang := range r.langs {
+		codes = append(codes, lang.Code)
+	}
+
+	absPath, fileName, hash := r.path, "stdin", ""
+	if r.path != "-" {
+		absPath, err = filepath.Abs(r.path)
+		if err != nil {
+			return extract.ImportRun{}, fmt.Errorf("error: %v", err)
+		}
+		fileName = filepath.Base(absPath)
+		files := []string{absPath}
+		if multiFile, ok := src.(extract.MultiFileSource); ok {
+			files = multiFile.Files()
+		}
+		hash, err = extract.HashFiles(files...)
+		if err != nil {
+			return extract.ImportRun{}, fmt.Errorf("error hashing '%s': %v", r.path, err)
+		}
+	} else if resume {
+		return extract.ImportRun{}, fmt.Errorf("error: an import from stdin cannot be resumed")
+	}
+
+	if resume {
+		return resumeImportRun(ctx, s, r, absPath, hash, codes, filterCfg)
+	}
+
+	dumpDate, err := parseDumpDate(r.dumpDate, fileName)
+	if err != nil {
+		return extract.ImportRun{}, err
+	}
+	source, err := s.db.UpsertSource(ctx, r.source)
+	if err != nil {
+		return extract.ImportRun{}, fmt.Errorf("error recording source: %v", err)
+	}
+	license := runLicense(r.license, src, source.License)
+	if license == "" {
+		fmt.Printf("Warning: no license is known for '%s'; record it with -license\n", r.path)
+	}
+
+	run, err := s.db.CreateImportRun(ctx, database.CreateImportRunParams{
+		FilePath:     absPath,
+		FileName:     fileName,
+		FileHash:     hash,
+		SourceID:     source.ID,
+		Langs:        codes,
+		DumpDate:     dumpDate,
+		License:      license,
+		FilterConfig: filterCfg,
+	})
+	if err != nil {
+		return extract.ImportRun{}, fmt.Errorf("error recording import run: %v", err)
+	}
+	return extract.ImportRun{ID: run.ID}, nil
+}
+
+// resumeImportRun finds the last import of the raw file at absPath, checking it
+// can be resumed by import r of the file with the hash hash, language codes
+// codes and filter config filterCfg, as JSON.
+func resumeImportRun(ctx context.Context, s *state, r importRun, absPath, hash string, codes []string, filterCfg []byte) (extract.ImportRun, error) {
+	run, err := s.db.GetLastImportRun(ctx, absPath)
+	if errors.Is(err, sql.ErrNoRows) {
+		return extract.ImportRun{}, fmt.Errorf("error: no import of '%s' to resume", r.path)
+	}
+	if err != nil {
+		return extract.ImportRun{}, fmt.Errorf("error finding import run: %v", err)
+	}
+
+	if err := checkResumable(run, r, hash, codes, filterCfg); err != nil {
+		return extract.ImportRun{}, err
+	}
+	fmt.Printf("Resuming import run %d after %d records...\n", run.ID, run.Checkpoint)
+	return extract.ImportRun{ID: run.ID, Checkpoint: run.Checkpoint}, nil
+}
+
+// checkResumable checks that the import run can be resumed by import r of its
+// raw file with the hash hash, language codes codes and filter config
+// filterCfg, as JSON.
+func checkResumable(run database.GetLastImportRunRow, r importRun, hash string, codes []string, filterCfg []byte) error {
+	// Compare filter configs as marshalled by this version, since the database
+	// reorders the keys of JSON objects
+	var runFilterCfg extract.FilterConfig
+	if err := json.Unmarshal(run.FilterConfig, &runFilterCfg); err != nil {
+		return fmt.Errorf("error unmarshalling filter config of import run %d: %v", run.ID, err)
+	}
+	runFilterCfgJSON, err := json.Marshal(runFilterCfg)
+	if err != nil {
+		return fmt.Errorf("error marshalling filter config: %v", err)
+	}
+
+	switch {
+	case run.Completed:
+		return fmt.Errorf("error: the last import of '%s' (run %d) already completed", r.path, run.ID)
+	case run.FileHash != hash:
+		return fmt.Errorf("error: '%s' changed since import run %d; rerun makeDB without -resume", r.path, run.ID)
+	case run.SourceName != r.source || !slices.Equal(run.Langs, codes):
+		return fmt.Errorf("error: import run %d read '%s' with -source %s -lang %s", run.ID, r.path,
+			run.SourceName, strings.Join(run.Langs, ","))
+	case !bytes.Equal(runFilterCfgJSON, filterCfg):
+		return fmt.Errorf("error: the filter config changed since import run %d", run.ID)
+	}
+	return nil
+}
+
+// runLicense returns the license to record for an import of the file read by
+// src: license if set, else the license the file states if src is an
+// extract.LicensedSource, else sourceLicense, that of the source format.
+func runLicense(license string, src extract.Source, sourceLicense string) string {
+	if licensed, ok := src.(extract.LicensedSource); ok && license == "" {
+		license = licensed.License()
+	}
+	if license == "" {
+		license = sourceLicense
+	}
+	return license
+}
+
+// parseDumpDate parses the dump date dumpDate, as YYYY-MM-DD, or finds one in
+// fileName if it is empty. The date is null if neither gives one.
+func parseDumpDate(dumpDate, fileName string) (sql.NullTime, error) {
+	if dumpDate != "" {
+		date, err := time.Parse(time.DateOnly, dumpDate)
+		if err != nil {
+			return sql.NullTime{}, fmt.Errorf("error: '%s' is not a date as YYYY-MM-DD", dumpDate)
+		}
+		return sql.NullTime{Time: date, Valid: true}, nil
+	}
+
+	match := dumpDatePattern.FindStringSubmatch(fileName)
+	if match == nil {
+		return sql.NullTime{}, nil
+	}
+	date, err := time.Parse("20060102", match[1]+match[2]+match[3])
+	if err != nil {
+		return sql.NullTime{}, nil // Not a date after all
+	}
+	return sql.NullTime{Time: date, Valid: true}, nil
+}
+
+// parseLenRange converts the <minWordLen> and <maxWordLen> args to integers and
+// ensures minLen < maxLen.
+func parseLenRange(minLenStr, maxLenStr string) (minLen, maxLen int, err error) {
+	minLen, err = strconv.Atoi(minLenStr)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error: '%s' is not convertable to an integer", minLenStr)
 	}
-	maxLen, err := strconv.Atoi(maxLenStr)
+	maxLen, err = strconv.Atoi(maxLenStr)
 	if err != nil {
-		return fmt.Errorf("error: '%s' is not convertable to an integer", maxLenStr)
+		return 0, 0, fmt.Errorf("error: '%s' is not convertable to an integer", maxLenStr)
 	}
 
 	// Ensure minLen < maxLen
 	if minLen >= maxLen {
-		return fmt.Errorf("error: <minLen> must be less than <maxLen>")
+		return 0, 0, fmt.Errorf("error: <minLen> must be less than <maxLen>")
 	}
+	return minLen, maxLen, nil
+}
 
-	// Check for DAWGSaveDir and existing file name
+// newDAWGSavePath returns the path of the .gob file named saveName in the
+// configured DAWG save directory, ensuring the directory exists and the file
+// does not.
+func newDAWGSavePath(s *state, saveName string) (string, error) {
 	dawgDir := s.cfg.DAWGSaveDirPath
+	if dawgDir == nil {
+		return "", fmt.Errorf("error: no DAWG save directory configured, set dawg_save_dir_path in the config")
+	}
 	if _, err := os.Stat(*dawgDir); os.IsNotExist(err) {
-		return fmt.Errorf("error: directory '%s' does not exist", *dawgDir)
+		return "", fmt.Errorf("error: directory '%s' does not exist", *dawgDir)
 	}
-	saveFileName := args[2] + ".gob"
-	dawgSavePath := filepath.Join(*dawgDir, saveFileName)
-	_, err = os.Stat(dawgSavePath)
+	saveFileName := saveName + ".gob"
+	savePath := filepath.Join(*dawgDir, saveFileName)
+	_, err := os.Stat(savePath)
 	if err != nil {
 		if !os.IsNotExist(err) {
-			return err
+			return "", err
 		}
 	} else {
-		return fmt.Errorf("error: file '%s' already exists", dawgSavePath)
+		return "", fmt.Errorf("error: file '%s' already exists", savePath)
+	}
+	return savePath, nil
+}
+
+// wordSelection holds the optional args selecting which words in the DB, or in
+// a word list, are used to build a DAWG or GADDAG.
+type wordSelection struct {
+	from        *string
+	lang        *string
+	excludeTags *string
+	inflections *bool
+}
+
+// addWordSelectionFlags defines the flags of a wordSelection on fs.
+func addWordSelectionFlags(fs *flag.FlagSet) wordSelection {
+	return wordSelection{
+		from:        fs.String("from", "", "word list to read words from instead of the DB, or - for stdin"),
+		lang:        fs.String("lang", "en", "language code of the words to include"),
+		excludeTags: fs.String("excludeTags", "", "comma separated sense tags of the words to leave out"),
+		inflections: fs.Bool("inflections", true, "include words that are only inflected forms of other words"),
+	}
+}
+
+// checkFrom rejects the flags set on fs other than -from if a word list is
+// given with it, since they select words in the DB.
+func (sel wordSelection) checkFrom(fs *flag.FlagSet) error {
+	if *sel.from == "" {
+		return nil
+	}
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name != "from" && err == nil {
+			err = fmt.Errorf("error: -%s cannot be used with -from", f.Name)
+		}
+	})
+	return err
+}
+
+// splitList splits a comma separated arg, returning an empty list for "".
+func splitList(arg string) []string {
+	if arg == "" {
+		return []string{}
+	}
+	return strings.Split(arg, ",")
+}
+
+// getSortedWords gets the words chosen by sel with lengths between minLen and
+// maxLen (inclusive) from the DB, or from the word list sel.from if set, in
+// lexicographical order.
+func getSortedWords(ctx context.Context, s *state, minLen, maxLen int, sel wordSelection) ([]string, error) {
+	if *sel.from != "" {
+		return readSortedWords(*sel.from, minLen, maxLen)
+	}
+	if err := s.requireDB(); err != nil {
+		return nil, err
 	}
 
-	// Get sorted words within range from DB
 	fmt.Print("Getting words from db... ")
-	sortedWords, err := s.db.GetWordsWithLenInRangeSorted(context.Background(), database.GetWordsWithLenInRangeSortedParams{
-		Minlen: fmt.Sprintf("%d", minLen),
-		Maxlen: fmt.Sprintf("%d", maxLen),
+	sortedWords, err := s.db.GetWordsWithLenInRangeSorted(ctx, database.GetWordsWithLenInRangeSortedParams{
+		Minlen:       fmt.Sprintf("%d", minLen),
+		Maxlen:       fmt.Sprintf("%d", maxLen),
+		Lang:         *sel.lang,
+		Excludedtags: splitList(*sel.excludeTags),
+		Includeforms: *sel.inflections,
 	})
 	if err != nil {
-		return fmt.Errorf("error: could not get words from db\n%v", err)
+		return nil, fmt.Errorf("error: could not get words from db\n%v", err)
+	}
+	fmt.Printf("Done!\nFound %d words!\n\n", len(sortedWords))
+	return sortedWords, nil
+}
+
+// readSortedWords reads the words with lengths between minLen and maxLen
+// (inclusive) from the word list at path, or stdin if path is "-". Words are
+// lowercased, sorted and deduplicated. Lines that are not a single word of
+// letters, e.g. with spaces, digits or punctuation, are skipped. The list may be
+// gzipped.
+func readSortedWords(path string, minLen, maxLen int) ([]string, error) {
+	fmt.Print("Reading words... ")
+	src, err := wordlist.NewSource(path, "")
+	if err != nil {
+		return nil, fmt.Errorf("error: could not open word list\n%v", err)
+	}
+	defer src.Close()
+
+	words := []string{}
+	skipped := 0
+	for {
+		entry, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error: could not read word list\n%v", err)
+		}
+		word := strings.ToLower(entry.Word)
+		if strings.ContainsFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }) {
+			skipped++
+			continue
+		}
+		if n := utf8.RuneCountInString(word); n >= minLen && n <= maxLen {
+			words = append(words, word)
+		}
+	}
+	slices.Sort(words)
+	words = slices.Compact(words)
+	fmt.Printf("Done!\nFound %d words!\n", len(words))
+	if skipped > 0 {
+		fmt.Printf("Skipped %d lines that are not words of letters only\n", skipped)
+	}
+	fmt.Println()
+	return words, nil
+}
+
+func commandMakeDAWG(ctx context.Context, s *state, args ...string) error {
+	fs := newFlagSet("makeDAWG")
+	sel := addWordSelectionFlags(fs)
+	withSounds := fs.Bool("sounds", false, "also save the pronunciations and syllables of the words")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := sel.checkFrom(fs); err != nil {
+		return err
+	}
+	args = fs.Args()
+
+	// Check for proper number of args
+	if len(args) != 3 {
+		return fmt.Errorf("error: expected 3 arguments, '%d' given", len(args))
+	}
+
+	// Ensure first two args can be converted to integers
+	minLen, maxLen, err := parseLenRange(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	// Check for DAWGSaveDir and existing file name
+	dawgSavePath, err := newDAWGSavePath(s, args[2])
+	if err != nil {
+		return err
+	}
+	soundsSavePath := strings.TrimSuffix(dawgSavePath, ".gob") + ".sounds.json"
+	if *withSounds {
+		if err := s.requireDB(); err != nil {
+			return err
+		}
+		if _, err := os.Stat(soundsSavePath); err == nil {
+			return fmt.Errorf("error: file '%s' already exists", soundsSavePath)
+		}
+	}
+
+	// Get sorted words within range from DB
+	sortedWords, err := getSortedWords(ctx, s, minLen, maxLen, sel)
+	if err != nil {
+		return err
 	}
 	totalWords := len(sortedWords)
-	fmt.Printf("Done!\nFound %d words!\n\n", totalWords)
 
 	// Make DAWG from sorted words list
 	fmt.Println("Building DAWG...")
 	builder := dawg.NewDAWGBuilder()
 	for i, w := range sortedWords {
+		if ctx.Err() != nil {
+			fmt.Printf("\nInterrupted after adding %d of %d words, no DAWG was saved.\n", i, totalWords)
+			return fmt.Errorf("error: makeDAWG interrupted")
+		}
 		err := builder.Insert(w)
 		if err != nil {
 			return fmt.Errorf("error: could not insert '%s'", w)
@@ -177,5 +686,434 @@ func commandMakeDAWG(s *state, args ...string) error {
 	}
 	fmt.Printf("Done!\n")
 
+	if *withSounds {
+		fmt.Printf("Saving sounds to '%s'... ", soundsSavePath)
+		err = saveSounds(ctx, s, finalDAWG, *sel.lang, soundsSavePath)
+		if err != nil && ctx.Err() != nil {
+			fmt.Printf("\nInterrupted, no sounds were saved. The DAWG was saved, and must be removed to make it again.\n")
+			return fmt.Errorf("error: makeDAWG interrupted")
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Done!\n")
+	}
+
+	return nil
+}
+
+// wordSounds is the pronunciation data of one word in a .sounds.json file.
+type wordSounds struct {
+	IPA         []string `json:"ipa,omitempty"`
+	Rhymes      []string `json:"rhymes,omitempty"`
+	Hyphenation string   `json:"hyphenation,omitempty"`
+	Syllables   int      `json:"syllables,omitempty"`
+}
+
+// saveSounds saves the sounds in the language lang of the words in d to a JSON
+// file at savePath, as an array whose element i holds the sounds of d.WordAt(i).
+func saveSounds(ctx context.Context, s *state, d *dawg.DAWG, lang, savePath string) error {
+	pronunciations, err := s.db.GetPronunciationsByLang(ctx, lang)
+	if err != nil {
+		return fmt.Errorf("error: could not get pronunciations from db\n%v", err)
+	}
+	hyphenations, err := s.db.GetHyphenationsByLang(ctx, lang)
+	if err != nil {
+		return fmt.Errorf("error: could not get hyphenations from db\n%v", err)
+	}
+
+	allSounds := make([]wordSounds, d.Len())
+	for _, p := range pronunciations {
+		i, ok := d.Index(p.Word)
+		if !ok {
+			continue
+		}
+		if p.Ipa != "" && !slices.Contains(allSounds[i].IPA, p.Ipa) {
+			allSounds[i].IPA = append(allSounds[i].IPA, p.Ipa)
+		}
+		if p.Rhyme != "" && !slices.Contains(allSounds[i].Rhymes, p.Rhyme) {
+			allSounds[i].Rhymes = append(allSounds[i].Rhymes, p.Rhyme)
+		}
+	}
+	for _, h := range hyphenations {
+		if i, ok := d.Index(h.Word); ok {
+			allSounds[i].Hyphenation = h.Hyphenation
+			allSounds[i].Syllables = int(h.Syllables)
+		}
+	}
+
+	f, err := os.Create(savePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(allSounds)
+}
+
+func commandMakeGADDAG(ctx context.Context, s *state, args ...string) error {
+	fs := newFlagSet("makeGADDAG")
+	sel := addWordSelectionFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := sel.checkFrom(fs); err != nil {
+		return err
+	}
+	args = fs.Args()
+
+	// Check for proper number of args
+	if len(args) != 3 {
+		return fmt.Errorf("error: expected 3 arguments, '%d' given", len(args))
+	}
+
+	// Ensure first two args can be converted to integers
+	minLen, maxLen, err := parseLenRange(args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	// Check for DAWGSaveDir and existing file name
+	gaddagSavePath, err := newDAWGSavePath(s, args[2])
+	if err != nil {
+		return err
+	}
+
+	// Get sorted words within range from DB
+	sortedWords, err := getSortedWords(ctx, s, minLen, maxLen, sel)
+	if err != nil {
+		return err
+	}
+
+	// Make GADDAG from sorted words list
+	fmt.Print("Building GADDAG... ")
+	finalGADDAG, err := gaddag.Build(sortedWords)
+	if err != nil {
+		return fmt.Errorf("error: could not build GADDAG\n%v", err)
+	}
+	if ctx.Err() != nil {
+		fmt.Printf("\nInterrupted, no GADDAG was saved.\n")
+		return fmt.Errorf("error: makeGADDAG interrupted")
+	}
+	fmt.Printf("Done!\n\n")
+
+	// Save GADDAG to file
+	fmt.Printf("Saving GADDAG to '%s'... ", gaddagSavePath)
+	err = finalGADDAG.SaveAsGob(gaddagSavePath)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Done!\n")
+
+	return nil
+}
+
+func commandThesaurus(ctx context.Context, s *state, args ...string) error {
+	fs := newFlagSet("thesaurus")
+	langCode := fs.String("lang", "en", "language code of the word")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	args = fs.Args()
+
+	// Check for proper number of args
+	if len(args) != 1 {
+		return fmt.Errorf("error: expected 1 argument, '%d' given", len(args))
+	}
+	if err := s.requireDB(); err != nil {
+		return err
+	}
+
+	word := strings.ToLower(args[0])
+	wordID, err := s.db.GetIDByWord(ctx, database.GetIDByWordParams{
+		Word: word,
+		Lang: *langCode,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		fmt.Printf("No related words found for '%s'\n", word)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error: could not get word from db\n%v", err)
+	}
+
+	related, err := s.db.GetRelatedWords(ctx, wordID)
+	if err != nil {
+		return fmt.Errorf("error: could not get related words from db\n%v", err)
+	}
+	if len(related) == 0 {
+		fmt.Printf("No related words found for '%s'\n", word)
+		return nil
+	}
+
+	// Print a heading for each synset followed by a line for each relation
+	for i, r := range related {
+		if i == 0 || r.SynsetKey != related[i-1].SynsetKey {
+			fmt.Printf("%s (%s)\n", word, r.Definition)
+		}
+		if i == 0 || r.SynsetKey != related[i-1].SynsetKey || r.RelType != related[i-1].RelType {
+			fmt.Printf("  %s: %s", r.RelType, r.Word)
+		} else {
+			fmt.Printf(", %s", r.Word)
+		}
+		if i == len(related)-1 || r.SynsetKey != related[i+1].SynsetKey || r.RelType != related[i+1].RelType {
+			fmt.Println()
+		}
+	}
+	return nil
+}
+
+func commandProvenance(ctx context.Context, s *state, args ...string) error {
+	fs := newFlagSet("provenance")
+	langCode := fs.String("lang", "en", "language code of the word")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	args = fs.Args()
+
+	// Check for proper number of args
+	if len(args) != 1 {
+		return fmt.Errorf("error: expected 1 argument, '%d' given", len(args))
+	}
+	if err := s.requireDB(); err != nil {
+		return err
+	}
+
+	word := strings.ToLower(args[0])
+	runs, err := s.db.GetImportRunsByWord(ctx, database.GetImportRunsByWordParams{
+		Word: word,
+		Lang: *langCode,
+	})
+	if err != nil {
+		return fmt.Errorf("error: could not get import runs from db\n%v", err)
+	}
+	if len(runs) == 0 {
+		fmt.Printf("No recorded imports kept '%s'\n", word)
+		return nil
+	}
+
+	for _, run := range runs {
+		added := []string{}
+		if run.AddedWord {
+			added = append(added, "the word")
+		}
+		if run.Definitions > 0 {
+			added = append(added, fmt.Sprintf("%d definition(s)", run.Definitions))
+		}
+		kept := fmt.Sprintf("Import run %d kept '%s' on %s", run.ID, word, run.StartedAt.Format(time.DateTime))
+		if len(added) > 0 {
+			kept += ", adding " + strings.Join(added, " and ")
+		}
+		fmt.Println(kept)
+
+		dumpDate := "unknown"
+		if run.DumpDate.Valid {
+			dumpDate = run.DumpDate.Time.Format(time.DateOnly)
+		}
+		license := run.License
+		if license == "" {
+			license = "unknown"
+		}
+		source := run.SourceName
+		if run.Url != "" {
+			source += " (" + run.Url + ")"
+		}
+		fmt.Printf("  Source: %s\n", source)
+		fmt.Printf("  File: %s (dump date %s)\n", run.FileName, dumpDate)
+		fmt.Printf("  License: %s\n", license)
+		fmt.Printf("  Filter config: %s\n", run.FilterConfig)
+	}
 	return nil
 }
+
+func commandRhymes(ctx context.Context, s *state, args ...string) error {
+	fs := newFlagSet("rhymes")
+	langCode := fs.String("lang", "en", "language code of the words")
+	rhyme := fs.String("rhyme", "", "rhyme of the words to list")
+	syllables := fs.Int("syllables", 0, "number of syllables of the words to list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	args = fs.Args()
+
+	// Check for proper number of args
+	if len(args) > 1 {
+		return fmt.Errorf("error: expected at most 1 argument, '%d' given", len(args))
+	}
+	if err := s.requireDB(); err != nil {
+		return err
+	}
+	if *syllables < 0 {
+		return fmt.Errorf("error: <n> must not be negative")
+	}
+
+	word := ""
+	rhymes := []string{*rhyme}
+	if len(args) == 1 {
+		if *rhyme != "" {
+			return fmt.Errorf("error: [word] and -rhyme cannot both be given")
+		}
+		word = strings.ToLower(args[0])
+		var err error
+		rhymes, err = s.db.GetRhymesByWord(ctx, database.GetRhymesByWordParams{
+			Word: word,
+			Lang: *langCode,
+		})
+		if err != nil {
+			return fmt.Errorf("error: could not get rhymes from db\n%v", err)
+		}
+		if len(rhymes) == 0 {
+			fmt.Printf("No rhymes found for '%s'\n", word)
+			return nil
+		}
+	} else if *rhyme == "" && *syllables == 0 {
+		return fmt.Errorf("error: one of [word], -rhyme or -syllables must be given")
+	}
+
+	for _, r := range rhymes {
+		words, err := s.db.GetWordsBySounds(ctx, database.GetWordsBySoundsParams{
+			Lang:      *langCode,
+			Rhyme:     r,
+			Syllables: int32(*syllables),
+		})
+		if err != nil {
+			return fmt.Errorf("error: could not get words from db\n%v", err)
+		}
+		words = slices.DeleteFunc(words, func(w string) bool {
+			return w == word
+		})
+
+		heading := "Words"
+		if r != "" {
+			heading += " rhyming with " + r
+		}
+		if *syllables > 0 {
+			heading += fmt.Sprintf(" with %d syllables", *syllables)
+		}
+		if len(words) > 0 {
+			fmt.Printf("%s (%d)...\n", heading, len(words))
+			for _, w := range words {
+				fmt.Printf("  %s\n", w)
+			}
+		} else {
+			fmt.Printf("%s: none found\n", heading)
+		}
+	}
+	return nil
+}
+
+func commandSolveGrid(ctx context.Context, s *state, args ...string) error {
+
+	// Check for proper number of args
+	if len(args) < 3 {
+		return fmt.Errorf("error: expected at least 3 arguments, '%d' given", len(args))
+	}
+
+	minLenStr := args[1]
+	minLen, err := strconv.Atoi(minLenStr)
+	if err != nil {
+		return fmt.Errorf("error: '%s' is not convertable to an integer", minLenStr)
+	}
+
+	// Load DAWG from the configured save directory
+	if s.cfg.DAWGSaveDirPath == nil {
+		return fmt.Errorf("error: no DAWG save directory configured, set dawg_save_dir_path in the config")
+	}
+	dawgPath := filepath.Join(*s.cfg.DAWGSaveDirPath, args[0]+".gob")
+	d, err := dawg.LoadDAWGFromGob(dawgPath)
+	if err != nil {
+		return err
+	}
+
+	words, err := grid.Solve(d, grid.ParseGrid(args[2:]), minLen)
+	if err != nil {
+		return fmt.Errorf("error: could not solve grid\n%v", err)
+	}
+	if len(words) > 0 {
+		fmt.Printf("Found %d words...\n", len(words))
+		for _, w := range words {
+			fmt.Printf("  %s\n", w)
+		}
+	} else {
+		fmt.Println("No words found")
+	}
+	return nil
+}
+
+func commandDefine(ctx context.Context, s *state, args ...string) error {
+	fs := newFlagSet("define")
+	langCode := fs.String("lang", "en", "language code of the word")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	args = fs.Args()
+
+	// Check for proper number of args
+	if len(args) != 1 {
+		return fmt.Errorf("error: expected 1 argument, '%d' given", len(args))
+	}
+	if err := s.requireDB(); err != nil {
+		return err
+	}
+
+	word := strings.ToLower(args[0])
+	found, err := printDefinitions(ctx, s, word, *langCode, "")
+	if err != nil {
+		return err
+	}
+
+	// Also define the lemmas of inflected forms, e.g. "run" for "ran"
+	lemmas, err := s.db.GetLemmasByForm(ctx, database.GetLemmasByFormParams{
+		Form: word,
+		Lang: *langCode,
+	})
+	if err != nil {
+		return fmt.Errorf("error: could not get lemmas from db\n%v", err)
+	}
+	defined := make(map[string]bool)
+	for _, lemma := range lemmas {
+		tags := ""
+		if len(lemma.Tags) > 0 {
+			tags = strings.Join(lemma.Tags, " ") + " "
+		}
+		fmt.Printf("\n%s is a %sform of the %s %s\n", word, tags, lemma.Pos, lemma.Lemma)
+		if defined[lemma.Lemma] {
+			continue
+		}
+		defined[lemma.Lemma] = true
+		lemmaFound, err := printDefinitions(ctx, s, lemma.Lemma, *langCode, "  ")
+		if err != nil {
+			return err
+		}
+		found = found || lemmaFound
+	}
+
+	if !found && len(lemmas) == 0 {
+		fmt.Printf("No definitions found for '%s'\n", word)
+	}
+	return nil
+}
+
+// printDefinitions prints every definition of word in the language lang, with
+// each line starting with indent. Returns whether any definitions were found.
+func printDefinitions(ctx context.Context, s *state, word, lang, indent string) (bool, error) {
+	defs, err := s.db.GetDefinitionsByWord(ctx, database.GetDefinitionsByWordParams{
+		Word: word,
+		Lang: lang,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error: could not get definitions from db\n%v", err)
+	}
+
+	// Print a heading for each etymology, pos pair followed by its senses
+	for i, def := range defs {
+		if i == 0 || def.EtymologyNumber != defs[i-1].EtymologyNumber || def.Pos != defs[i-1].Pos {
+			if def.EtymologyNumber > 0 {
+				fmt.Printf("%s%s (%s, etymology %d)\n", indent, word, def.Pos, def.EtymologyNumber)
+			} else {
+				fmt.Printf("%s%s (%s)\n", indent, word, def.Pos)
+			}
+		}
+		fmt.Printf("%s  %d. %s\n", indent, def.SenseIndex+1, def.Definition)
+	}
+	return len(defs) > 0, nil
+}
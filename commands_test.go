@@ -0,0 +1,498 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pbojar/dictextract/internal/database"
+	"github.com/pbojar/dictextract/internal/extract"
+	"github.com/pbojar/dictextract/internal/wordlist"
+	"github.com/pbojar/dictextract/internal/wordnet"
+)
+
+func TestCheckResumable(t *testing.T) {
+	filterCfg, err := json.Marshal(extract.DefaultFilterConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCfg := extract.DefaultFilterConfig()
+	otherCfg.MinLength++
+	otherFilterCfg, err := json.Marshal(otherCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The database reorders the keys of JSON objects
+	var reordered map[string]any
+	if err := json.Unmarshal(filterCfg, &reordered); err != nil {
+		t.Fatal(err)
+	}
+	reorderedFilterCfg, err := json.Marshal(reordered)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resumable := database.GetLastImportRunRow{
+		ID:           7,
+		SourceName:   "wiktionary",
+		FileHash:     "abc",
+		Langs:        []string{"en", "fr"},
+		FilterConfig: reorderedFilterCfg,
+		Checkpoint:   1000,
+	}
+	r := importRun{source: "wiktionary", path: "dump.jsonl"}
+	tests := []struct {
+		name    string
+		modify  func(run *database.GetLastImportRunRow)
+		wantErr string
+	}{
+		{"resumable", func(run *database.GetLastImportRunRow) {}, ""},
+		{"completed", func(run *database.GetLastImportRunRow) { run.Completed = true }, "already completed"},
+		{"file changed", func(run *database.GetLastImportRunRow) { run.FileHash = "def" }, "changed since import run 7"},
+		{"other source", func(run *database.GetLastImportRunRow) { run.SourceName = "wordnet" }, "with -source wordnet -lang en,fr"},
+		{"other languages", func(run *database.GetLastImportRunRow) { run.Langs = []string{"en"} }, "with -source wiktionary -lang en"},
+		{"filter config changed", func(run *database.GetLastImportRunRow) { run.FilterConfig = otherFilterCfg }, "filter config changed"},
+		{"invalid filter config", func(run *database.GetLastImportRunRow) { run.FilterConfig = []byte("[") }, "error unmarshalling"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := resumable
+			tt.modify(&run)
+			err := checkResumable(run, r, "abc", []string{"en", "fr"}, filterCfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkResumable() error = %v, want none", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkResumable() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"no word list", []string{"-lang", "fr", "-excludeTags", "rare", "-sounds"}, ""},
+		{"word list only", []string{"-from", "words.txt"}, ""},
+		{"lang", []string{"-from", "words.txt", "-lang", "fr"}, "-lang cannot be used with -from"},
+		{"excludeTags", []string{"-excludeTags", "rare", "-from", "-"}, "-excludeTags cannot be used with -from"},
+		{"inflections", []string{"-from", "words.txt", "-inflections=false"}, "-inflections cannot be used with -from"},
+		{"default value", []string{"-from", "words.txt", "-lang", "en"}, "-lang cannot be used with -from"},
+		{"sounds", []string{"-from", "words.txt", "-sounds"}, "-sounds cannot be used with -from"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := newFlagSet("makeDAWG")
+			sel := addWordSelectionFlags(fs)
+			fs.Bool("sounds", false, "")
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := sel.checkFrom(fs)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkFrom() error = %v, want none", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkFrom() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+const testWordList = `# A comment
+cat
+Dog
+été
+cat
+ice cream
+r2d2
+don't
+  bird
+a
+catalogue
+`
+
+func TestReadSortedWords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte(testWordList), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name           string
+		minLen, maxLen int
+		want           []string
+	}{
+		{"all", 1, 20, []string{"a", "bird", "cat", "catalogue", "dog", "été"}},
+		{"length bounds in runes", 3, 4, []string{"bird", "cat", "dog", "été"}},
+		{"none in bounds", 10, 20, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readSortedWords(path, tt.minLen, tt.maxLen)
+			if err != nil {
+				t.Fatalf("readSortedWords() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("readSortedWords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSortedWordsStdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() {
+		os.Stdin = stdin
+		r.Close()
+	}()
+	go func() {
+		io.WriteString(w, "zebra\nant\nAnt\n")
+		w.Close()
+	}()
+
+	got, err := readSortedWords("-", 1, 10)
+	if err != nil {
+		t.Fatalf("readSortedWords() error = %v", err)
+	}
+	if want := []string{"ant", "zebra"}; !slices.Equal(got, want) {
+		t.Errorf("readSortedWords() = %v, want %v", got, want)
+	}
+}
+
+func TestReadSortedWordsMissing(t *testing.T) {
+	if _, err := readSortedWords(filepath.Join(t.TempDir(), "missing.txt"), 1, 10); err == nil {
+		t.Errorf("readSortedWords() of a missing file did not fail")
+	}
+}
+
+func TestParseDumpDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		dumpDate string
+		fileName string
+		want     string // As YYYY-MM-DD, "" for none
+		wantErr  bool
+	}{
+		{"Wiktionary dump", "", "enwiktionary-20240501-pages-articles.xml", "2024-05-01", false},
+		{"Wiktextract dump", "", "raw-wiktextract-data.2025-03-17.jsonl.gz", "2025-03-17", false},
+		{"date first", "", "20231130.jsonl", "2023-11-30", false},
+		{"no date", "", "english-wordnet-2024.xml.gz", "", false},
+		{"too many digits", "", "list-202405011.txt", "", false},
+		{"not a date", "", "words-20241399.txt", "", false},
+		{"stdin", "", "stdin", "", false},
+		{"given date", "2020-01-02", "enwiktionary-20240501.jsonl", "2020-01-02", false},
+		{"invalid given date", "20200102", "words.txt", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDumpDate(tt.dumpDate, tt.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDumpDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			gotDate := ""
+			if got.Valid {
+				gotDate = got.Time.Format(time.DateOnly)
+			}
+			if gotDate != tt.want {
+				t.Errorf("parseDumpDate(%q, %q) = %q, want %q", tt.dumpDate, tt.fileName, gotDate, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunLicense(t *testing.T) {
+	dir := t.TempDir()
+	lmfPath := filepath.Join(dir, "wn.xml")
+	lmf := `<LexicalResource><Lexicon id="t" language="en" license="https://example.org/wn"></Lexicon></LexicalResource>`
+	if err := os.WriteFile(lmfPath, []byte(lmf), 0644); err != nil {
+		t.Fatal(err)
+	}
+	listPath := filepath.Join(dir, "words.txt")
+	if err := os.WriteFile(listPath, []byte("cat\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	wn, err := wordnet.NewSource(lmfPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wn.Close()
+	list, err := wordlist.NewSource(listPath, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer list.Close()
+
+	tests := []struct {
+		name          string
+		license       string
+		src           extract.Source
+		sourceLicense string
+		want          string
+	}{
+		{"stated in the file", "", wn, "", "https://example.org/wn"},
+		{"given over the file's", "CC0", wn, "", "CC0"},
+		{"of the source format", "", list, "CC BY-SA 4.0, GFDL", "CC BY-SA 4.0, GFDL"},
+		{"given over the source format's", "CC0", list, "CC BY-SA 4.0, GFDL", "CC0"},
+		{"unknown", "", list, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runLicense(tt.license, tt.src, tt.sourceLicense); got != tt.want {
+				t.Errorf("runLicense() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// recordingConnector opens connections to a fake database standing in for
+// Postgres. UpsertWords creates every word, and the other statements are only
+// recorded, by query name, once their transaction is committed.
+type recordingConnector struct {
+	mu        sync.Mutex
+	nextID    int64
+	committed [][]recordedStmt // The statements of every committed transaction
+}
+
+type recordedStmt struct {
+	name string
+	args []driver.NamedValue
+}
+
+func (c *recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return &recordingConn{c: c}, nil
+}
+func (c *recordingConnector) Driver() driver.Driver { return nil }
+
+type recordingConn struct {
+	c  *recordingConnector
+	tx []recordedStmt
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+func (c *recordingConn) Close() error              { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) { return c, nil }
+
+// CheckNamedValue passes arguments such as pq arrays to the conn unconverted.
+func (c *recordingConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c *recordingConn) Commit() error {
+	c.c.mu.Lock()
+	defer c.c.mu.Unlock()
+	c.c.committed = append(c.c.committed, c.tx)
+	c.tx = nil
+	return nil
+}
+
+func (c *recordingConn) Rollback() error {
+	c.tx = nil
+	return nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.tx = append(c.tx, recordedStmt{queryName(query), args})
+	return driver.RowsAffected(0), nil
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	name := queryName(query)
+	if name != "UpsertWords" {
+		return nil, fmt.Errorf("unexpected query %s", name)
+	}
+	c.tx = append(c.tx, recordedStmt{name, args})
+	c.c.mu.Lock()
+	defer c.c.mu.Unlock()
+	rows := &wordRows{words: *args[0].Value.(*pq.StringArray), langs: *args[1].Value.(*pq.StringArray), firstID: c.c.nextID}
+	c.c.nextID += int64(len(rows.words))
+	return rows, nil
+}
+
+// queryName returns the name of a query generated by sqlc, from its first line.
+func queryName(query string) string {
+	name, _, _ := strings.Cut(strings.TrimPrefix(query, "-- name: "), " ")
+	return name
+}
+
+// wordRows are the rows of UpsertWords creating words.
+type wordRows struct {
+	words, langs []string
+	firstID      int64
+	i            int
+}
+
+func (r *wordRows) Columns() []string { return []string{"id", "word", "lang", "created"} }
+func (r *wordRows) Close() error      { return nil }
+
+func (r *wordRows) Next(dest []driver.Value) error {
+	if r.i == len(r.words) {
+		return io.EOF
+	}
+	dest[0], dest[1], dest[2], dest[3] = r.firstID+int64(r.i), r.words[r.i], r.langs[r.i], true
+	r.i++
+	return nil
+}
+
+// cancellingSource is a source of n distinct words of letters, which cancels
+// an import by calling cancel when record cancelAt is read.
+type cancellingSource struct {
+	n, cancelAt int
+	cancel      context.CancelFunc
+	read        int
+}
+
+func (s *cancellingSource) Next() (extract.Entry, error) {
+	if s.read == s.cancelAt {
+		s.cancel()
+	}
+	if s.read >= s.n {
+		return extract.Entry{}, io.EOF
+	}
+	s.read++
+	return extract.Entry{Word: letterWord(s.read - 1), Lang: "en"}, nil
+}
+
+func (s *cancellingSource) Close() error { return nil }
+
+// letterWord returns i written in base 26 with the letters a to z as digits.
+func letterWord(i int) string {
+	word := ""
+	for {
+		word = string(rune('a'+i%26)) + word
+		i /= 26
+		if i == 0 {
+			return word
+		}
+	}
+}
+
+// captureStdout returns what f prints to stdout.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	printed := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(r)
+		printed <- string(b)
+	}()
+	defer func() {
+		os.Stdout = stdout
+		w.Close()
+		r.Close()
+	}()
+	f()
+	os.Stdout = stdout
+	w.Close()
+	return <-printed
+}
+
+func TestImportInterrupted(t *testing.T) {
+	en, err := extract.LookupLanguage("en", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	connector := &recordingConnector{nextID: 1}
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	src := &cancellingSource{n: 3000, cancelAt: 1500, cancel: cancel}
+	var importErr error
+	printed := captureStdout(t, func() {
+		importErr = extract.ToDB(ctx, src, []extract.Language{en}, extract.FilterConfig{}, db, 2, extract.ImportRun{ID: 7})
+	})
+	if !errors.Is(importErr, context.Canceled) {
+		t.Fatalf("ToDB() error = %v, want %v", importErr, context.Canceled)
+	}
+
+	// A full batch, then the batch staged when interrupted
+	if len(connector.committed) != 2 {
+		t.Fatalf("committed %d transactions, want 2", len(connector.committed))
+	}
+	words := []string{}
+	var checkpoint int64
+	for _, tx := range connector.committed {
+		for _, stmt := range tx {
+			switch stmt.name {
+			case "UpsertWords":
+				words = append(words, *stmt.args[0].Value.(*pq.StringArray)...)
+			case "UpdateImportRunCheckpoint":
+				if id := stmt.args[0].Value.(int32); id != 7 {
+					t.Errorf("checkpointed import run %d, want 7", id)
+				}
+				checkpoint = stmt.args[1].Value.(int64)
+			}
+		}
+	}
+	if len(words) <= 1000 || len(words) > src.cancelAt+1 {
+		t.Fatalf("committed %d words, want more than a batch and at most the %d read before cancelling",
+			len(words), src.cancelAt+1)
+	}
+	for i, word := range words {
+		if word != letterWord(i) {
+			t.Fatalf("committed word %d = %q, want %q", i, word, letterWord(i))
+		}
+	}
+	if checkpoint != int64(len(words)) {
+		t.Errorf("checkpoint = %d, want the %d records read", checkpoint, len(words))
+	}
+	if want := fmt.Sprintf("The first %d records of the source are imported.", checkpoint); !strings.Contains(printed, want) {
+		t.Errorf("ToDB() printed %q, want %q", printed, want)
+	}
+
+	tests := []struct {
+		path     string
+		wantHint string
+		wantErr  string
+	}{
+		{"words.txt", "Resume the import with: makeDB -source wordlist -lang en -resume 'words.txt'\n",
+			"error: makeDB interrupted"},
+		{"-", "", "error: makeDB interrupted, imports from stdin cannot be resumed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			var err error
+			printed := captureStdout(t, func() {
+				err = importInterrupted("wordlist", []extract.Language{en}, tt.path)
+			})
+			if printed != tt.wantHint {
+				t.Errorf("importInterrupted() printed %q, want %q", printed, tt.wantHint)
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("importInterrupted() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,4 +4,5 @@ go 1.24.5
 
 require (
 	github.com/lib/pq v1.10.9
+	golang.org/x/text v0.28.0
 )
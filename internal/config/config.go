@@ -12,6 +12,9 @@ type Config struct {
 	DBURL           *string `json:"db_url"`
 	RawDictDirPath  *string `json:"raw_dict_dir_path"`
 	DAWGSaveDirPath *string `json:"dawg_save_dir_path"`
+	// FilterConfigPath is optional. If set, it points to a JSON filter config
+	// used by makeDB in place of the default word policy.
+	FilterConfigPath *string `json:"filter_config_path,omitempty"`
 }
 
 func getConfigFilePath() (string, error) {
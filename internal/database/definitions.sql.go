@@ -7,49 +7,158 @@ package database
 
 import (
 	"context"
-)
+	"database/sql"
 
-const createDefinition = `-- name: CreateDefinition :one
-INSERT INTO definitions (id, word_id, pos_id, "definition")
-VALUES (
-    DEFAULT,
-    $1,
-    $2,
-    $3
+	"github.com/lib/pq"
 )
-RETURNING id, word_id, pos_id, definition
+
+const getDefinitionsByWord = `-- name: GetDefinitionsByWord :many
+SELECT parts_of_speech.pos, definitions.etymology_number, definitions.sense_index, definitions."definition"
+FROM definitions
+JOIN words ON words.id = definitions.word_id
+JOIN parts_of_speech ON parts_of_speech.id = definitions.pos_id
+WHERE words.word = $1 AND words.lang = $2
+ORDER BY definitions.etymology_number, definitions.pos_id, definitions.sense_index
 `
 
-type CreateDefinitionParams struct {
-	WordID     int32
-	PosID      int32
-	Definition string
+type GetDefinitionsByWordParams struct {
+	Word string
+	Lang string
 }
 
-func (q *Queries) CreateDefinition(ctx context.Context, arg CreateDefinitionParams) (Definition, error) {
-	row := q.db.QueryRowContext(ctx, createDefinition, arg.WordID, arg.PosID, arg.Definition)
-	var i Definition
-	err := row.Scan(
-		&i.ID,
-		&i.WordID,
-		&i.PosID,
-		&i.Definition,
-	)
-	return i, err
+type GetDefinitionsByWordRow struct {
+	Pos             string
+	EtymologyNumber int32
+	SenseIndex      int32
+	Definition      string
+}
+
+func (q *Queries) GetDefinitionsByWord(ctx context.Context, arg GetDefinitionsByWordParams) ([]GetDefinitionsByWordRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDefinitionsByWord, arg.Word, arg.Lang)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDefinitionsByWordRow
+	for rows.Next() {
+		var i GetDefinitionsByWordRow
+		if err := rows.Scan(
+			&i.Pos,
+			&i.EtymologyNumber,
+			&i.SenseIndex,
+			&i.Definition,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExistingDefinitionKeys = `-- name: GetExistingDefinitionKeys :many
+SELECT DISTINCT definitions.word_id, definitions.pos_id, definitions.etymology_number
+FROM definitions
+JOIN unnest($1::int[], $2::int[], $3::int[]) AS input(word_id, pos_id, etymology_number)
+ON definitions.word_id = input.word_id AND definitions.pos_id = input.pos_id
+AND definitions.etymology_number = input.etymology_number
+`
+
+type GetExistingDefinitionKeysParams struct {
+	WordIds          []int32
+	PosIds           []int32
+	EtymologyNumbers []int32
+}
+
+type GetExistingDefinitionKeysRow struct {
+	WordID          int32
+	PosID           int32
+	EtymologyNumber int32
+}
+
+func (q *Queries) GetExistingDefinitionKeys(ctx context.Context, arg GetExistingDefinitionKeysParams) ([]GetExistingDefinitionKeysRow, error) {
+	rows, err := q.db.QueryContext(ctx, getExistingDefinitionKeys, pq.Array(arg.WordIds), pq.Array(arg.PosIds), pq.Array(arg.EtymologyNumbers))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetExistingDefinitionKeysRow
+	for rows.Next() {
+		var i GetExistingDefinitionKeysRow
+		if err := rows.Scan(&i.WordID, &i.PosID, &i.EtymologyNumber); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const definitionExists = `-- name: DefinitionExists :one
-SELECT EXISTS(SELECT 1 FROM definitions WHERE word_id=$1 AND pos_id=$2)
+const createDefinitions = `-- name: CreateDefinitions :many
+INSERT INTO definitions (word_id, pos_id, "definition", etymology_number, sense_index, import_run_id)
+SELECT input.*, $1::int FROM unnest(
+    $2::int[],
+    $3::int[],
+    $4::text[],
+    $5::int[],
+    $6::int[]
+) AS input
+RETURNING id, word_id, pos_id, etymology_number, sense_index
 `
 
-type DefinitionExistsParams struct {
-	WordID int32
-	PosID  int32
+type CreateDefinitionsParams struct {
+	ImportRunID      sql.NullInt32
+	WordIds          []int32
+	PosIds           []int32
+	Definitions      []string
+	EtymologyNumbers []int32
+	SenseIndexes     []int32
+}
+
+type CreateDefinitionsRow struct {
+	ID              int32
+	WordID          int32
+	PosID           int32
+	EtymologyNumber int32
+	SenseIndex      int32
 }
 
-func (q *Queries) DefinitionExists(ctx context.Context, arg DefinitionExistsParams) (bool, error) {
-	row := q.db.QueryRowContext(ctx, definitionExists, arg.WordID, arg.PosID)
-	var exists bool
-	err := row.Scan(&exists)
-	return exists, err
+func (q *Queries) CreateDefinitions(ctx context.Context, arg CreateDefinitionsParams) ([]CreateDefinitionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, createDefinitions,
+		arg.ImportRunID,
+		pq.Array(arg.WordIds),
+		pq.Array(arg.PosIds),
+		pq.Array(arg.Definitions),
+		pq.Array(arg.EtymologyNumbers),
+		pq.Array(arg.SenseIndexes),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CreateDefinitionsRow
+	for rows.Next() {
+		var i CreateDefinitionsRow
+		if err := rows.Scan(&i.ID, &i.WordID, &i.PosID, &i.EtymologyNumber, &i.SenseIndex); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
@@ -4,19 +4,117 @@
 
 package database
 
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
 type Definition struct {
+	ID              int32
+	WordID          int32
+	PosID           int32
+	Definition      string
+	EtymologyNumber int32
+	SenseIndex      int32
+	ImportRunID     sql.NullInt32
+}
+
+type DefinitionTag struct {
+	DefinitionID int32
+	TagID        int32
+}
+
+type Hyphenation struct {
+	WordID      int32
+	Hyphenation string
+	Syllables   int32
+}
+
+type ImportRun struct {
+	ID           int32
+	FilePath     string
+	FileHash     string
+	Langs        []string
+	Checkpoint   int64
+	Completed    bool
+	StartedAt    time.Time
+	UpdatedAt    time.Time
+	SourceID     int32
+	FileName     string
+	DumpDate     sql.NullTime
+	License      string
+	FilterConfig json.RawMessage
+}
+
+type PartsOfSpeech struct {
+	ID  int32
+	Pos string
+}
+
+type Pronunciation struct {
+	ID     int32
+	WordID int32
+	Ipa    string
+	Rhyme  string
+	Audio  string
+	Tags   []string
+}
+
+type SenseRelation struct {
+	SenseKey  string
+	TargetKey string
+	RelType   string
+}
+
+type Source struct {
+	ID      int32
+	Name    string
+	License string
+	Url     string
+}
+
+type Synset struct {
 	ID         int32
-	WordID     int32
+	SynsetKey  string
 	PosID      int32
 	Definition string
 }
 
-type PartsOfSpeech struct {
+type SynsetRelation struct {
+	SynsetKey string
+	TargetKey string
+	RelType   string
+}
+
+type Tag struct {
 	ID  int32
-	Pos string
+	Tag string
+}
+
+type WordForm struct {
+	ID    int32
+	Form  string
+	Lemma string
+	Lang  string
+	PosID int32
+	Tags  []string
+}
+
+type WordImportRun struct {
+	WordID      int32
+	ImportRunID int32
+	Added       bool
+}
+
+type WordSense struct {
+	SenseKey  string
+	WordID    int32
+	SynsetKey string
 }
 
 type Word struct {
 	ID   int32
 	Word string
+	Lang string
 }
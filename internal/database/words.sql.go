@@ -7,46 +7,89 @@ package database
 
 import (
 	"context"
+
+	"github.com/lib/pq"
 )
 
-const createWord = `-- name: CreateWord :one
-INSERT INTO words (id, word)
-VALUES (
-    DEFAULT, 
-    $1
-) 
-RETURNING id, word
+const addWordImportRuns = `-- name: AddWordImportRuns :exec
+INSERT INTO word_import_runs (word_id, import_run_id, added)
+SELECT input.word_id, $1::int, input.added
+FROM unnest($2::int[], $3::boolean[]) AS input(word_id, added)
+ON CONFLICT DO NOTHING
 `
 
-func (q *Queries) CreateWord(ctx context.Context, word string) (Word, error) {
-	row := q.db.QueryRowContext(ctx, createWord, word)
-	var i Word
-	err := row.Scan(&i.ID, &i.Word)
-	return i, err
+type AddWordImportRunsParams struct {
+	ImportRunID int32
+	WordIds     []int32
+	Added       []bool
+}
+
+func (q *Queries) AddWordImportRuns(ctx context.Context, arg AddWordImportRunsParams) error {
+	_, err := q.db.ExecContext(ctx, addWordImportRuns, arg.ImportRunID, pq.Array(arg.WordIds), pq.Array(arg.Added))
+	return err
 }
 
 const getIDByWord = `-- name: GetIDByWord :one
-SELECT id FROM words WHERE word=$1
+SELECT id FROM words WHERE word=$1 AND lang=$2
 `
 
-func (q *Queries) GetIDByWord(ctx context.Context, word string) (int32, error) {
-	row := q.db.QueryRowContext(ctx, getIDByWord, word)
+type GetIDByWordParams struct {
+	Word string
+	Lang string
+}
+
+func (q *Queries) GetIDByWord(ctx context.Context, arg GetIDByWordParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, getIDByWord, arg.Word, arg.Lang)
 	var id int32
 	err := row.Scan(&id)
 	return id, err
 }
 
 const getWordsWithLenInRangeSorted = `-- name: GetWordsWithLenInRangeSorted :many
-SELECT word FROM words WHERE CHAR_LENGTH(word) BETWEEN $1 AND $2 ORDER BY word ASC
+SELECT word FROM words
+WHERE CHAR_LENGTH(word) BETWEEN $1 AND $2 AND lang=$3
+AND NOT (
+    EXISTS (SELECT 1 FROM definitions WHERE definitions.word_id = words.id)
+    AND NOT EXISTS (
+        SELECT 1 FROM definitions
+        WHERE definitions.word_id = words.id AND NOT EXISTS (
+            SELECT 1 FROM definition_tags
+            JOIN tags ON tags.id = definition_tags.tag_id
+            WHERE definition_tags.definition_id = definitions.id AND tags.tag = ANY($4::text[])
+        )
+    )
+)
+AND ($5::boolean OR NOT EXISTS (
+    SELECT 1 FROM word_forms AS f
+    WHERE f.form = words.word AND f.lang = words.lang AND f.lemma <> f.form
+    AND NOT EXISTS (SELECT 1 FROM word_forms AS l WHERE l.lemma = words.word AND l.lang = words.lang)
+))
+ORDER BY word COLLATE "C" ASC
 `
 
 type GetWordsWithLenInRangeSortedParams struct {
-	Minlen string
-	Maxlen string
+	Minlen       string
+	Maxlen       string
+	Lang         string
+	Excludedtags []string
+	Includeforms bool
 }
 
+// A word is left out only if every one of its definitions has an excluded tag,
+// so words with a single rare or obsolete sense are kept, as are words without
+// definitions.
+// Unless forms are included, words that are only inflected forms of other
+// words are left out. A form that is also the lemma of some form, e.g. "saw"
+// as the past of "see" and a noun with the plural "saws", is a word of its
+// own and kept, as is a form whose lemma is itself.
 func (q *Queries) GetWordsWithLenInRangeSorted(ctx context.Context, arg GetWordsWithLenInRangeSortedParams) ([]string, error) {
-	rows, err := q.db.QueryContext(ctx, getWordsWithLenInRangeSorted, arg.Minlen, arg.Maxlen)
+	rows, err := q.db.QueryContext(ctx, getWordsWithLenInRangeSorted,
+		arg.Minlen,
+		arg.Maxlen,
+		arg.Lang,
+		pq.Array(arg.Excludedtags),
+		arg.Includeforms,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -67,3 +110,53 @@ func (q *Queries) GetWordsWithLenInRangeSorted(ctx context.Context, arg GetWords
 	}
 	return items, nil
 }
+
+const upsertWords = `-- name: UpsertWords :many
+WITH input AS (
+    SELECT * FROM unnest($1::text[], $2::text[]) AS input(word, lang)
+), inserted AS (
+    INSERT INTO words (word, lang)
+    SELECT word, lang FROM input
+    ON CONFLICT DO NOTHING
+    RETURNING id, word, lang
+)
+SELECT inserted.id, inserted.word, inserted.lang, true AS created FROM inserted
+UNION ALL
+SELECT words.id, words.word, words.lang, false AS created FROM words
+JOIN input ON input.word = words.word AND input.lang = words.lang
+`
+
+type UpsertWordsParams struct {
+	Words []string
+	Langs []string
+}
+
+type UpsertWordsRow struct {
+	ID      int32
+	Word    string
+	Lang    string
+	Created bool
+}
+
+func (q *Queries) UpsertWords(ctx context.Context, arg UpsertWordsParams) ([]UpsertWordsRow, error) {
+	rows, err := q.db.QueryContext(ctx, upsertWords, pq.Array(arg.Words), pq.Array(arg.Langs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UpsertWordsRow
+	for rows.Next() {
+		var i UpsertWordsRow
+		if err := rows.Scan(&i.ID, &i.Word, &i.Lang, &i.Created); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,124 @@
+package dawg
+
+import (
+	"slices"
+	"sort"
+)
+
+// RackMatch is a word found by a rack search. Blanks holds the (rune) positions
+// in Word that were filled by blank tiles, in ascending order.
+type RackMatch struct {
+	Word   string
+	Blanks []int
+}
+
+// Anagrams returns every word in the DAWG that uses all of the letters in rack
+// exactly once. The rack is treated as a multiset, so repeated letters may be
+// used as many times as they appear. Words are returned in ascending order.
+func (d *DAWG) Anagrams(rack string) []string {
+	return matchedWords(d.rackSearch(rack, 0, true))
+}
+
+// SubAnagrams returns every word in the DAWG that can be built from a subset of
+// the letters in rack, each letter used at most as many times as it appears.
+// Words are returned in ascending order.
+func (d *DAWG) SubAnagrams(rack string) []string {
+	return matchedWords(d.rackSearch(rack, 0, false))
+}
+
+// AnagramsWithBlanks is like Anagrams, but the rack also holds the given number
+// of blank tiles, each of which can stand for any letter. Every blank must be
+// used. A word that can be made with the blanks in different positions is
+// returned once per distinct placement so the caller can score each one.
+func (d *DAWG) AnagramsWithBlanks(rack string, blanks int) []RackMatch {
+	return d.rackSearch(rack, blanks, true)
+}
+
+// SubAnagramsWithBlanks is like SubAnagrams, but the rack also holds the given
+// number of blank tiles, each of which can stand for any letter. As with
+// AnagramsWithBlanks, each distinct placement of the blanks is returned.
+func (d *DAWG) SubAnagramsWithBlanks(rack string, blanks int) []RackMatch {
+	return d.rackSearch(rack, blanks, false)
+}
+
+// rackSearch walks the DAWG depth first, only following edges labelled with a
+// letter still left in the rack, or any edge while blanks remain. If useAll is
+// true, a word is only accepted when the rack has been emptied. Matches are
+// sorted by word; placements of the same word keep the order in which they were
+// found, which tries real tiles before blanks.
+func (d *DAWG) rackSearch(rack string, blanks int, useAll bool) []RackMatch {
+	// Count the letters in the rack and sort the distinct ones so results come
+	// out in lexicographical order
+	counts := make(map[rune]int)
+	remaining := max(blanks, 0)
+	for _, r := range rack {
+		counts[r]++
+		remaining++
+	}
+	letters := make([]rune, 0, len(counts))
+	for r := range counts {
+		letters = append(letters, r)
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i] < letters[j]
+	})
+
+	matches := []RackMatch{}
+	word := make([]rune, 0, remaining)
+	blankPos := []int{}
+	var search func(node *DAWGNode, blanks, remaining int)
+	search = func(node *DAWGNode, blanks, remaining int) {
+		if node.isTerminal && len(word) > 0 && (!useAll || remaining == 0) {
+			matches = append(matches, RackMatch{
+				Word:   string(word),
+				Blanks: slices.Clone(blankPos),
+			})
+		}
+		if remaining == 0 {
+			return
+		}
+
+		// Only the rack letters can be followed unless a blank is available
+		candidates := letters
+		if blanks > 0 {
+			candidates = node.sortedKeys()
+		}
+		for _, r := range candidates {
+			child, ok := node.children[r]
+			if !ok {
+				continue
+			}
+			if counts[r] > 0 {
+				counts[r]--
+				word = append(word, r)
+				search(child, blanks, remaining-1)
+				word = word[:len(word)-1]
+				counts[r]++
+			}
+			if blanks > 0 {
+				blankPos = append(blankPos, len(word))
+				word = append(word, r)
+				search(child, blanks-1, remaining-1)
+				word = word[:len(word)-1]
+				blankPos = blankPos[:len(blankPos)-1]
+			}
+		}
+	}
+	search(d.root, max(blanks, 0), remaining)
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Word < matches[j].Word
+	})
+	return matches
+}
+
+// matchedWords returns the distinct words of a sorted slice of matches.
+func matchedWords(matches []RackMatch) []string {
+	words := []string{}
+	for _, m := range matches {
+		if len(words) == 0 || words[len(words)-1] != m.Word {
+			words = append(words, m.Word)
+		}
+	}
+	return words
+}
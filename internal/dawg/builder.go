@@ -1,13 +1,18 @@
 package dawg
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"unicode/utf8"
+)
 
 // DAWGBuilder is used to construct a new DAWG.
 // Words MUST be inserted in lexicographical order.
+// Words are handled rune by rune, so any UTF-8 text can be inserted.
 type DAWGBuilder struct {
 	root            *DAWGNode
 	registeredNodes map[string]*DAWGNode
-	lastWord        string
+	lastWord        []rune
 	nodeCounter     int
 }
 
@@ -35,15 +40,20 @@ func (b *DAWGBuilder) newNode() *DAWGNode {
 }
 
 // Insert adds a word to the DAWG. Words MUST be inserted in
-// lexicographical order for the algorithm to work correctly.
+// lexicographical order for the algorithm to work correctly. Byte order of
+// UTF-8 strings (as given by sort.Strings) is the same as rune order.
 func (b *DAWGBuilder) Insert(word string) (err error) {
-	if word < b.lastWord {
-		return fmt.Errorf("words must be inserted in lexicographical order: received '%s' after '%s'", word, b.lastWord)
+	if !utf8.ValidString(word) {
+		return fmt.Errorf("word '%s' is not valid UTF-8", word)
 	}
+	if word < string(b.lastWord) {
+		return fmt.Errorf("words must be inserted in lexicographical order: received '%s' after '%s'", word, string(b.lastWord))
+	}
+	runes := []rune(word)
 
-	// Find the common prefix length with the last word
+	// Find the common prefix length (in runes) with the last word
 	comPreLen := 0
-	for comPreLen < len(word) && comPreLen < len(b.lastWord) && word[comPreLen] == b.lastWord[comPreLen] {
+	for comPreLen < len(runes) && comPreLen < len(b.lastWord) && runes[comPreLen] == b.lastWord[comPreLen] {
 		comPreLen++
 	}
 
@@ -51,25 +61,20 @@ func (b *DAWGBuilder) Insert(word string) (err error) {
 	b.minimize(comPreLen)
 
 	// Add the new suffix for the current word.
-	var node *DAWGNode
-	if b.lastWord == "" {
-		node = b.root
-	} else {
-		// Find the node where the new suffix should branch off
-		node = b.root
-		for i := 0; i < comPreLen; i++ {
-			node = node.children[rune(b.lastWord[i])]
-		}
+	// Find the node where the new suffix should branch off
+	node := b.root
+	for _, r := range b.lastWord[:comPreLen] {
+		node = node.children[r]
 	}
 
 	// Add the new nodes for the current word's suffix.
-	for _, r := range word[comPreLen:] {
+	for _, r := range runes[comPreLen:] {
 		nextNode := b.newNode()
 		node.children[r] = nextNode
 		node = nextNode
 	}
 	node.isTerminal = true
-	b.lastWord = word
+	b.lastWord = runes
 	return nil
 }
 
@@ -86,7 +91,7 @@ func (b *DAWGBuilder) minimize(downTo int) {
 	for i := len(b.lastWord); i > downTo; i-- {
 		parent := path[i-1]
 		child := path[i]
-		char := rune(b.lastWord[i-1])
+		char := b.lastWord[i-1]
 
 		sig := child.signature()
 		if existingNode, ok := b.registeredNodes[sig]; ok {
@@ -99,8 +104,26 @@ func (b *DAWGBuilder) minimize(downTo int) {
 	}
 }
 
-// Finish minimizes the last word added, and returns the immutable DAWG.
+// Build creates a DAWG from words. The words need not be sorted and may contain
+// duplicates; a sorted copy without duplicates is inserted into a DAWGBuilder.
+func Build(words []string) (*DAWG, error) {
+	sorted := slices.Clone(words)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	builder := NewDAWGBuilder()
+	for _, w := range sorted {
+		if err := builder.Insert(w); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Finish(), nil
+}
+
+// Finish minimizes the last word added, counts the words reachable from each
+// node (used by Index and WordAt), and returns the immutable DAWG.
 func (b *DAWGBuilder) Finish() *DAWG {
 	b.minimize(0) // Minimize last word
+	b.root.countWords(make(map[*DAWGNode]bool))
 	return &DAWG{root: b.root}
 }
@@ -1,10 +1,18 @@
 package dawg
 
+import "iter"
+
 // DAWG is an immutable Directed Acyclic Word Graph.
 type DAWG struct {
 	root *DAWGNode
 }
 
+// Root returns the root node of the DAWG, from which the graph can be walked
+// with DAWGNode.Child.
+func (d *DAWG) Root() *DAWGNode {
+	return d.root
+}
+
 // Contains checks if a word exists in the DAWG.
 func (d *DAWG) Contains(word string) bool {
 	node := d.root
@@ -30,3 +38,45 @@ func (d *DAWG) StartsWith(prefix string) bool {
 	}
 	return true
 }
+
+// WordsWithPrefix returns the words in the DAWG starting with prefix, in
+// ascending rune order. At most limit words are returned; a limit of zero or
+// less returns them all.
+func (d *DAWG) WordsWithPrefix(prefix string, limit int) []string {
+	words := []string{}
+	node := d.root
+	for _, r := range prefix {
+		child, ok := node.children[r]
+		if !ok {
+			return words
+		}
+		node = child
+	}
+	node.walk([]rune(prefix), func(word string) bool {
+		words = append(words, word)
+		return limit <= 0 || len(words) < limit
+	})
+	return words
+}
+
+// Words returns an iterator over every word in the DAWG in ascending rune order.
+func (d *DAWG) Words() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		d.root.walk([]rune{}, yield)
+	}
+}
+
+// walk calls yield for every word reachable from n, where word holds the runes
+// on the path to n. Words are visited in ascending rune order. Returns false if
+// yield asked to stop.
+func (n *DAWGNode) walk(word []rune, yield func(string) bool) bool {
+	if n.isTerminal && len(word) > 0 && !yield(string(word)) {
+		return false
+	}
+	for _, r := range n.sortedKeys() {
+		if !n.children[r].walk(append(word, r), yield) {
+			return false
+		}
+	}
+	return true
+}
@@ -6,10 +6,29 @@ import (
 	"strings"
 )
 
+// DAWGNode is a state in a DAWG. Its exported methods let other packages walk
+// the graph one edge at a time, starting from DAWG.Root.
 type DAWGNode struct {
 	id         int
 	isTerminal bool
 	children   map[rune]*DAWGNode
+	numWords   int // Number of words reachable from this node, set by countWords
+}
+
+// Child returns the node reached from n along the edge labelled r, or nil if n
+// has no such edge.
+func (n *DAWGNode) Child(r rune) *DAWGNode {
+	return n.children[r]
+}
+
+// IsTerminal reports whether a word ends at n.
+func (n *DAWGNode) IsTerminal() bool {
+	return n.isTerminal
+}
+
+// Edges returns the labels of the edges leaving n in ascending order.
+func (n *DAWGNode) Edges() []rune {
+	return n.sortedKeys()
 }
 
 // signature returns a unique string for a DAWGNode based on its children. Used
@@ -23,6 +42,16 @@ func (n *DAWGNode) signature() string {
 	}
 
 	// Sort keys for a deterministic signature
+	for _, r := range n.sortedKeys() {
+		sb.WriteRune(r)
+		sb.WriteString(fmt.Sprintf("%d_", n.children[r].id))
+	}
+	return sb.String()
+}
+
+// sortedKeys returns the runes labelling the edges to the children of a DAWGNode
+// in ascending order. Used wherever a traversal must be deterministic.
+func (n *DAWGNode) sortedKeys() []rune {
 	keys := make([]rune, 0, len(n.children))
 	for r := range n.children {
 		keys = append(keys, r)
@@ -30,10 +59,23 @@ func (n *DAWGNode) signature() string {
 	sort.Slice(keys, func(i, j int) bool {
 		return keys[i] < keys[j]
 	})
+	return keys
+}
 
-	for _, r := range keys {
-		sb.WriteRune(r)
-		sb.WriteString(fmt.Sprintf("%d_", n.children[r].id))
+// countWords sets numWords on n and every node reachable from it. Nodes already
+// in counted are shared with an earlier path and are not visited again.
+func (n *DAWGNode) countWords(counted map[*DAWGNode]bool) int {
+	if counted[n] {
+		return n.numWords
 	}
-	return sb.String()
+	total := 0
+	if n.isTerminal {
+		total++
+	}
+	for _, child := range n.children {
+		total += child.countWords(counted)
+	}
+	n.numWords = total
+	counted[n] = true
+	return total
 }
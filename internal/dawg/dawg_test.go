@@ -2,6 +2,7 @@ package dawg
 
 import (
 	"os"
+	"slices"
 	"sort"
 	"testing"
 )
@@ -349,3 +350,535 @@ func TestSaveAsAndLoadFromGob(t *testing.T) {
 		}
 	}
 }
+
+// buildTestDAWG builds a DAWG from words, failing the test on error. Tests of
+// other packages use dawgtest.Build, which this package cannot import.
+func buildTestDAWG(t *testing.T, words []string) *DAWG {
+	t.Helper()
+	dawg, err := Build(words)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	return dawg
+}
+
+func TestBuild(t *testing.T) {
+	dawg := buildTestDAWG(t, []string{"cat", "at", "cat", "act"})
+	if dawg.Len() != 3 {
+		t.Errorf("Len() = %d, expected 3", dawg.Len())
+	}
+	for _, word := range []string{"act", "at", "cat"} {
+		if !dawg.Contains(word) {
+			t.Errorf("'%s' not found in dawg", word)
+		}
+	}
+
+	if _, err := Build([]string{"ok", "\xff"}); err == nil {
+		t.Errorf("Build() with invalid UTF-8 returned no error")
+	}
+}
+
+func TestDAWGAnagrams(t *testing.T) {
+	dawg := buildTestDAWG(t, []string{
+		"act",
+		"at",
+		"cat",
+		"cats",
+		"scat",
+		"tact",
+		"ta",
+		"a",
+	})
+
+	tests := []struct {
+		name     string
+		rack     string
+		subset   bool
+		expected []string
+	}{
+		{
+			name:     "Anagrams of tac",
+			rack:     "tac",
+			expected: []string{"act", "cat"},
+		},
+		{
+			name:     "Anagrams of tacs",
+			rack:     "tacs",
+			expected: []string{"cats", "scat"},
+		},
+		{
+			name:     "Repeated letters must all be used",
+			rack:     "tact",
+			expected: []string{"tact"},
+		},
+		{
+			name:     "No anagrams of xyz",
+			rack:     "xyz",
+			expected: []string{},
+		},
+		{
+			name:     "Sub-anagrams of tac",
+			rack:     "tac",
+			subset:   true,
+			expected: []string{"a", "act", "at", "cat", "ta"},
+		},
+		{
+			name:     "Sub-anagrams respect letter counts",
+			rack:     "tca",
+			subset:   true,
+			expected: []string{"a", "act", "at", "cat", "ta"},
+		},
+		{
+			name:     "Empty rack",
+			rack:     "",
+			subset:   true,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result []string
+			if tt.subset {
+				result = dawg.SubAnagrams(tt.rack)
+			} else {
+				result = dawg.Anagrams(tt.rack)
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("rack '%s' returned %v, expected %v", tt.rack, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDAWGAnagramsWithBlanks(t *testing.T) {
+	dawg := buildTestDAWG(t, []string{
+		"act",
+		"at",
+		"cat",
+		"cats",
+		"scat",
+		"tact",
+		"ta",
+		"a",
+	})
+
+	tests := []struct {
+		name     string
+		rack     string
+		blanks   int
+		subset   bool
+		expected []RackMatch
+	}{
+		{
+			name:   "Anagrams of at plus a blank",
+			rack:   "at",
+			blanks: 1,
+			expected: []RackMatch{
+				{Word: "act", Blanks: []int{1}},
+				{Word: "cat", Blanks: []int{0}},
+			},
+		},
+		{
+			name:   "All blanks",
+			rack:   "",
+			blanks: 2,
+			expected: []RackMatch{
+				{Word: "at", Blanks: []int{0, 1}},
+				{Word: "ta", Blanks: []int{0, 1}},
+			},
+		},
+		{
+			name:   "Sub-anagrams report each blank placement",
+			rack:   "a",
+			blanks: 1,
+			subset: true,
+			expected: []RackMatch{
+				{Word: "a", Blanks: []int{}},
+				{Word: "a", Blanks: []int{0}},
+				{Word: "at", Blanks: []int{1}},
+				{Word: "ta", Blanks: []int{0}},
+			},
+		},
+		{
+			name:     "No blanks behaves like Anagrams",
+			rack:     "tac",
+			blanks:   0,
+			expected: []RackMatch{{Word: "act", Blanks: []int{}}, {Word: "cat", Blanks: []int{}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result []RackMatch
+			if tt.subset {
+				result = dawg.SubAnagramsWithBlanks(tt.rack, tt.blanks)
+			} else {
+				result = dawg.AnagramsWithBlanks(tt.rack, tt.blanks)
+			}
+			if len(result) != len(tt.expected) {
+				t.Fatalf("rack '%s' with %d blanks returned %v, expected %v", tt.rack, tt.blanks, result, tt.expected)
+			}
+			for i, m := range result {
+				if m.Word != tt.expected[i].Word || !slices.Equal(m.Blanks, tt.expected[i].Blanks) {
+					t.Errorf("match %d is %v, expected %v", i, m, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDAWGMatch(t *testing.T) {
+	dawg := buildTestDAWG(t, []string{
+		"cat",
+		"car",
+		"cats",
+		"catch",
+		"cache",
+		"cot",
+		"cut",
+		"cutlery",
+		"dog",
+		"dogs",
+		"doggy",
+	})
+
+	tests := []struct {
+		name     string
+		pattern  string
+		expected []string
+	}{
+		{
+			name:     "Literal pattern",
+			pattern:  "dog",
+			expected: []string{"dog"},
+		},
+		{
+			name:     "Single letter wildcard",
+			pattern:  "c?t",
+			expected: []string{"cat", "cot", "cut"},
+		},
+		{
+			name:     "Trailing run wildcard",
+			pattern:  "c?t*",
+			expected: []string{"cat", "catch", "cats", "cot", "cut", "cutlery"},
+		},
+		{
+			name:     "Leading run wildcard",
+			pattern:  "*s",
+			expected: []string{"cats", "dogs"},
+		},
+		{
+			name:     "Adjacent run wildcards do not duplicate results",
+			pattern:  "**g*",
+			expected: []string{"dog", "doggy", "dogs"},
+		},
+		{
+			name:     "Run wildcard in the middle",
+			pattern:  "c*e",
+			expected: []string{"cache"},
+		},
+		{
+			name:     "No match",
+			pattern:  "??",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := dawg.Match(tt.pattern)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("dawg.Match(%s) returned %v, expected %v", tt.pattern, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDAWGWordsWithPrefix(t *testing.T) {
+	dawg := buildTestDAWG(t, []string{
+		"cat",
+		"car",
+		"cats",
+		"catch",
+		"cache",
+		"dog",
+		"dogs",
+		"doggy",
+	})
+
+	tests := []struct {
+		name     string
+		prefix   string
+		limit    int
+		expected []string
+	}{
+		{
+			name:     "All words starting with ca",
+			prefix:   "ca",
+			expected: []string{"cache", "car", "cat", "catch", "cats"},
+		},
+		{
+			name:     "Prefix is itself a word",
+			prefix:   "dog",
+			expected: []string{"dog", "doggy", "dogs"},
+		},
+		{
+			name:     "Limit results",
+			prefix:   "ca",
+			limit:    2,
+			expected: []string{"cache", "car"},
+		},
+		{
+			name:     "Unknown prefix",
+			prefix:   "cow",
+			expected: []string{},
+		},
+		{
+			name:     "Empty prefix",
+			prefix:   "",
+			limit:    3,
+			expected: []string{"cache", "car", "cat"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := dawg.WordsWithPrefix(tt.prefix, tt.limit)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("dawg.WordsWithPrefix(%s, %d) returned %v, expected %v", tt.prefix, tt.limit, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDAWGWords(t *testing.T) {
+	testWords := []string{
+		"cat",
+		"car",
+		"cats",
+		"catch",
+		"cache",
+		"dog",
+		"dogs",
+		"doggy",
+	}
+	dawg := buildTestDAWG(t, testWords)
+	sort.Strings(testWords)
+
+	result := slices.Collect(dawg.Words())
+	if !slices.Equal(result, testWords) {
+		t.Errorf("dawg.Words() returned %v, expected %v", result, testWords)
+	}
+
+	// Stopping early must not panic or keep yielding
+	count := 0
+	for range dawg.Words() {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	if count != 2 {
+		t.Errorf("iteration continued after break: %d words seen", count)
+	}
+}
+
+func TestDAWGIndexAndWordAt(t *testing.T) {
+	testWords := []string{
+		"cat",
+		"car",
+		"cats",
+		"catch",
+		"cache",
+		"dog",
+		"dogs",
+		"doggy",
+	}
+	dawg := buildTestDAWG(t, testWords)
+	sort.Strings(testWords)
+
+	if dawg.Len() != len(testWords) {
+		t.Fatalf("dawg.Len() returned %d, expected %d", dawg.Len(), len(testWords))
+	}
+
+	for i, word := range testWords {
+		index, ok := dawg.Index(word)
+		if !ok || index != i {
+			t.Errorf("dawg.Index(%s) returned (%d, %t), expected (%d, true)", word, index, ok, i)
+		}
+		result, ok := dawg.WordAt(i)
+		if !ok || result != word {
+			t.Errorf("dawg.WordAt(%d) returned (%s, %t), expected (%s, true)", i, result, ok, word)
+		}
+	}
+
+	for _, word := range []string{"ca", "do", "doggo", ""} {
+		if index, ok := dawg.Index(word); ok {
+			t.Errorf("dawg.Index(%s) returned (%d, true) for a missing word", word, index)
+		}
+	}
+	for _, index := range []int{-1, len(testWords)} {
+		if word, ok := dawg.WordAt(index); ok {
+			t.Errorf("dawg.WordAt(%d) returned (%s, true) for an out of range index", index, word)
+		}
+	}
+
+	// Counts must survive a round trip through a gob file
+	tF, err := os.CreateTemp("", "testDawg.gob")
+	if err != nil {
+		t.Fatalf("error creating temporary file: %v", err)
+	}
+	dawgFilePath := tF.Name()
+	tF.Close()
+	defer os.Remove(dawgFilePath)
+	if err := dawg.SaveAsGob(dawgFilePath); err != nil {
+		t.Fatalf("error saving as gob: %v", err)
+	}
+	loadedDAWG, err := LoadDAWGFromGob(dawgFilePath)
+	if err != nil {
+		t.Fatalf("error loading DAWG from gob: %v", err)
+	}
+	for i, word := range testWords {
+		if index, ok := loadedDAWG.Index(word); !ok || index != i {
+			t.Errorf("loadedDAWG.Index(%s) returned (%d, %t), expected (%d, true)", word, index, ok, i)
+		}
+	}
+}
+
+func TestDAWGFuzzySearch(t *testing.T) {
+	dawg := buildTestDAWG(t, []string{
+		"cat",
+		"car",
+		"cats",
+		"catch",
+		"cache",
+		"act",
+		"dog",
+		"dogs",
+		"doggy",
+	})
+
+	tests := []struct {
+		name        string
+		word        string
+		maxDistance int
+		expected    []FuzzyMatch
+	}{
+		{
+			name:        "Exact match only",
+			word:        "dog",
+			maxDistance: 0,
+			expected:    []FuzzyMatch{{"dog", 0}},
+		},
+		{
+			name:        "Substitution, insertion and deletion",
+			word:        "cat",
+			maxDistance: 1,
+			expected:    []FuzzyMatch{{"cat", 0}, {"act", 1}, {"car", 1}, {"cats", 1}},
+		},
+		{
+			name:        "Transposition counts as one edit",
+			word:        "dgo",
+			maxDistance: 1,
+			expected:    []FuzzyMatch{{"dog", 1}},
+		},
+		{
+			name:        "Transposition at the start",
+			word:        "cta",
+			maxDistance: 1,
+			expected:    []FuzzyMatch{{"cat", 1}},
+		},
+		{
+			name:        "Larger distance sorted by distance then word",
+			word:        "cach",
+			maxDistance: 2,
+			expected:    []FuzzyMatch{{"cache", 1}, {"catch", 1}, {"act", 2}, {"car", 2}, {"cat", 2}, {"cats", 2}},
+		},
+		{
+			name:        "No matches",
+			word:        "zebra",
+			maxDistance: 1,
+			expected:    []FuzzyMatch{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := dawg.FuzzySearch(tt.word, tt.maxDistance)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("dawg.FuzzySearch(%s, %d) returned %v, expected %v", tt.word, tt.maxDistance, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDAWGBuilderMultiByte(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+	}{
+		{
+			name:  "French",
+			words: []string{"été", "étés", "était", "étoile", "ete", "eté", "fée"},
+		},
+		{
+			name:  "Spanish",
+			words: []string{"año", "años", "ano", "niño", "niña", "ñandú"},
+		},
+		{
+			name:  "German",
+			words: []string{"über", "übel", "straße", "strasse", "öl", "ol"},
+		},
+		{
+			name:  "Polish",
+			words: []string{"żółw", "żółty", "źle", "zło", "łódź", "łódka"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dawg := buildTestDAWG(t, tt.words)
+			sorted := append([]string(nil), tt.words...)
+			sort.Strings(sorted)
+
+			for i, word := range sorted {
+				if !dawg.Contains(word) {
+					t.Errorf("'%s' not found in DAWG", word)
+				}
+				if index, ok := dawg.Index(word); !ok || index != i {
+					t.Errorf("dawg.Index(%s) returned (%d, %t), expected (%d, true)", word, index, ok, i)
+				}
+			}
+			result := slices.Collect(dawg.Words())
+			if !slices.Equal(result, sorted) {
+				t.Errorf("dawg.Words() returned %v, expected %v", result, sorted)
+			}
+
+			// Dropping the last rune must not leave a word behind
+			for _, word := range sorted {
+				runes := []rune(word)
+				prefix := string(runes[:len(runes)-1])
+				if !slices.Contains(sorted, prefix) && dawg.Contains(prefix) {
+					t.Errorf("DAWG contains '%s', which was never inserted", prefix)
+				}
+			}
+		})
+	}
+
+	// Runes sort by code point, so anagram and pattern searches work as usual
+	dawg := buildTestDAWG(t, []string{"été", "tee", "thé"})
+	if result := dawg.Anagrams("téé"); !slices.Equal(result, []string{"été"}) {
+		t.Errorf("dawg.Anagrams(téé) returned %v, expected [été]", result)
+	}
+	if result := dawg.Match("?t?"); !slices.Equal(result, []string{"été"}) {
+		t.Errorf("dawg.Match(?t?) returned %v, expected [été]", result)
+	}
+}
+
+func TestDAWGBuilderInvalidUTF8(t *testing.T) {
+	builder := NewDAWGBuilder()
+	if err := builder.Insert("caf\xe9"); err == nil {
+		t.Errorf("DAWGBuilder.Insert() accepted invalid UTF-8")
+	}
+}
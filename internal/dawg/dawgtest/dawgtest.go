@@ -0,0 +1,19 @@
+// Package dawgtest provides helpers for testing packages built on DAWGs.
+package dawgtest
+
+import (
+	"testing"
+
+	"github.com/pbojar/dictextract/internal/dawg"
+)
+
+// Build builds a DAWG from words, which need not be sorted, failing the test on
+// error.
+func Build(t testing.TB, words []string) *dawg.DAWG {
+	t.Helper()
+	d, err := dawg.Build(words)
+	if err != nil {
+		t.Fatalf("dawg.Build() error = %v", err)
+	}
+	return d
+}
@@ -0,0 +1,71 @@
+package dawg
+
+import (
+	"slices"
+	"sort"
+)
+
+// FuzzyMatch is a word found by FuzzySearch and its edit distance from the
+// searched word.
+type FuzzyMatch struct {
+	Word     string
+	Distance int
+}
+
+// FuzzySearch returns every word in the DAWG within maxDistance edits of word,
+// where an edit inserts, deletes or substitutes one letter, or transposes two
+// adjacent letters (the optimal string alignment variant of Damerau-Levenshtein
+// distance). Matches are sorted by distance, then by word.
+func (d *DAWG) FuzzySearch(word string, maxDistance int) []FuzzyMatch {
+	target := []rune(word)
+	n := len(target)
+
+	// The walk extends one row of the edit distance table per edge, giving the
+	// distance from each prefix of target to the path so far
+	firstRow := make([]int, n+1)
+	for j := range firstRow {
+		firstRow[j] = j
+	}
+
+	matches := []FuzzyMatch{}
+	path := []rune{}
+	var search func(node *DAWGNode, prevRow, prevPrevRow []int)
+	search = func(node *DAWGNode, prevRow, prevPrevRow []int) {
+		for _, r := range node.sortedKeys() {
+			row := make([]int, n+1)
+			row[0] = prevRow[0] + 1
+			for j := 1; j <= n; j++ {
+				cost := 1
+				if target[j-1] == r {
+					cost = 0
+				}
+				row[j] = min(prevRow[j]+1, row[j-1]+1, prevRow[j-1]+cost)
+				if prevPrevRow != nil && j > 1 && r == target[j-2] && path[len(path)-1] == target[j-1] {
+					row[j] = min(row[j], prevPrevRow[j-2]+1)
+				}
+			}
+
+			child := node.children[r]
+			path = append(path, r)
+			if child.isTerminal && row[n] <= maxDistance {
+				matches = append(matches, FuzzyMatch{Word: string(path), Distance: row[n]})
+			}
+
+			// A transposition can reach back two rows, so only prune once
+			// neither row can lead to a match
+			if slices.Min(row) <= maxDistance || slices.Min(prevRow)+1 <= maxDistance {
+				search(child, row, prevRow)
+			}
+			path = path[:len(path)-1]
+		}
+	}
+	if d.root.isTerminal && n <= maxDistance {
+		matches = append(matches, FuzzyMatch{Word: "", Distance: n})
+	}
+	search(d.root, firstRow, nil)
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Distance < matches[j].Distance
+	})
+	return matches
+}
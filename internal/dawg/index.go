@@ -0,0 +1,62 @@
+package dawg
+
+// Len returns the number of words in the DAWG.
+func (d *DAWG) Len() int {
+	return d.root.numWords
+}
+
+// Index returns the position of word in the ascending rune order of all words
+// in the DAWG, and whether the word was found. Indexes are dense, running from
+// 0 to Len()-1, so they can key flat arrays of per-word data.
+func (d *DAWG) Index(word string) (int, bool) {
+	index := 0
+	node := d.root
+	for _, r := range word {
+		// The word ending here and every word under a smaller edge come first
+		if node.isTerminal {
+			index++
+		}
+		for _, k := range node.sortedKeys() {
+			if k >= r {
+				break
+			}
+			index += node.children[k].numWords
+		}
+		child, ok := node.children[r]
+		if !ok {
+			return 0, false
+		}
+		node = child
+	}
+	if !node.isTerminal {
+		return 0, false
+	}
+	return index, true
+}
+
+// WordAt returns the word with the given index (see Index), and false if the
+// index is out of range.
+func (d *DAWG) WordAt(index int) (string, bool) {
+	if index < 0 || index >= d.root.numWords {
+		return "", false
+	}
+	word := []rune{}
+	node := d.root
+	for {
+		if node.isTerminal {
+			if index == 0 {
+				return string(word), true
+			}
+			index--
+		}
+		for _, k := range node.sortedKeys() {
+			child := node.children[k]
+			if index < child.numWords {
+				word = append(word, k)
+				node = child
+				break
+			}
+			index -= child.numWords
+		}
+	}
+}
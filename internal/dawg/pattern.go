@@ -0,0 +1,84 @@
+package dawg
+
+import "slices"
+
+// Wildcards understood by Match.
+const (
+	// AnyLetter matches exactly one letter.
+	AnyLetter = '?'
+	// AnyRun matches any run of letters, including an empty one.
+	AnyRun = '*'
+)
+
+// Match returns every word in the DAWG matching pattern, where '?' stands for
+// exactly one letter and '*' for any run of letters (including none). All other
+// runes must match literally, e.g. "c?t*" matches "cat", "cots" and "cutlery".
+// Words are returned in ascending order.
+func (d *DAWG) Match(pattern string) []string {
+	p := []rune(pattern)
+
+	// The pattern is run as an NFA alongside a depth first walk of the DAWG. A
+	// state is a position in p; each step of the walk advances every live state,
+	// so a word is reached by exactly one path and is never reported twice.
+	start := closure(p, []int{0})
+
+	words := []string{}
+	word := []rune{}
+	var search func(node *DAWGNode, states []int)
+	search = func(node *DAWGNode, states []int) {
+		if node.isTerminal && len(word) > 0 && slices.Contains(states, len(p)) {
+			words = append(words, string(word))
+		}
+		for _, r := range node.sortedKeys() {
+			next := step(p, states, r)
+			if len(next) == 0 {
+				continue
+			}
+			word = append(word, r)
+			search(node.children[r], next)
+			word = word[:len(word)-1]
+		}
+	}
+	search(d.root, start)
+	return words
+}
+
+// step returns the states reachable from states after consuming r.
+func step(p []rune, states []int, r rune) []int {
+	next := []int{}
+	for _, s := range states {
+		if s == len(p) {
+			continue
+		}
+		switch p[s] {
+		case AnyRun:
+			// '*' consumes r and stays put
+			next = append(next, s)
+		case AnyLetter:
+			next = append(next, s+1)
+		default:
+			if p[s] == r {
+				next = append(next, s+1)
+			}
+		}
+	}
+	return closure(p, next)
+}
+
+// closure adds the states reachable by letting each '*' match nothing and
+// removes duplicates.
+func closure(p []rune, states []int) []int {
+	out := []int{}
+	for _, s := range states {
+		for {
+			if !slices.Contains(out, s) {
+				out = append(out, s)
+			}
+			if s == len(p) || p[s] != AnyRun {
+				break
+			}
+			s++
+		}
+	}
+	return out
+}
@@ -10,9 +10,11 @@ import (
 
 // SerializableDAWGNode is a serializable version of DAWGNode.
 // Its children map is a map of integer IDs that replaces the map of pointers.
+// NumWords is zero in files saved before word counts were stored.
 type SerializableDAWGNode struct {
 	IsTerminal bool
 	Children   map[rune]int
+	NumWords   int
 }
 
 // SerializableDAWG is the main structure for serialization.
@@ -64,6 +66,7 @@ func (d *DAWG) SaveAsGob(path string) error {
 		sNodes[i] = SerializableDAWGNode{
 			IsTerminal: node.isTerminal,
 			Children:   sChildren,
+			NumWords:   node.numWords,
 		}
 	}
 
@@ -111,6 +114,7 @@ func LoadDAWGFromGob(path string) (*DAWG, error) {
 			id:         i, // The new ID is the slice index
 			isTerminal: sDAWG.Nodes[i].IsTerminal,
 			children:   make(map[rune]*DAWGNode),
+			numWords:   sDAWG.Nodes[i].NumWords,
 		}
 	}
 
@@ -126,5 +130,11 @@ func LoadDAWGFromGob(path string) (*DAWG, error) {
 		root: nodes[sDAWG.RootID],
 	}
 
+	// Recount words for files saved without them. Every non-root node leads to
+	// at least one word, so a zero count means the counts are missing.
+	if dawg.root.numWords == 0 && len(dawg.root.children) > 0 {
+		dawg.root.countWords(make(map[*DAWGNode]bool))
+	}
+
 	return dawg, nil
 }
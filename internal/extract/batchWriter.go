@@ -0,0 +1,500 @@
+package extract
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"maps"
+	"strings"
+
+	"github.com/pbojar/dictextract/internal/database"
+)
+
+// batchSize is the number of kept entries written to the database per
+// transaction.
+const batchSize = 1000
+
+// arraySeparator joins the elements of the array columns (e.g. tags) of each
+// row in a multi-row insert, since arrays of arrays cannot be passed as a
+// parameter. Tags and the like never contain it.
+const arraySeparator = "\x1f"
+
+// stagedEntry is a kept entry waiting to be written by a batchWriter.
+type stagedEntry struct {
+	entry Entry // With its word and pos lowercased
+	defs  []definition
+	forms []wordForm
+}
+
+// batchStats counts the entries and definitions added by writing a batch.
+type batchStats struct {
+	added int
+	dupes int
+	defs  int
+}
+
+// batchWriter stages kept entries and writes them to the database in batches,
+// each in a single transaction, with a few multi-row upserts per table rather
+// than several round trips per entry. The IDs of parts of speech and tags,
+// which are few and shared by most entries, are cached.
+type batchWriter struct {
+	db      *sql.DB
+	runID   int32 // Import run of the words and new definitions, checkpointed per batch, if not 0
+	entries []stagedEntry
+	posIDs  map[string]int32
+	tagIDs  map[string]int32
+}
+
+func newBatchWriter(db *sql.DB, runID int32) *batchWriter {
+	return &batchWriter{
+		db:     db,
+		runID:  runID,
+		posIDs: make(map[string]int32),
+		tagIDs: make(map[string]int32),
+	}
+}
+
+// add stages entry e with its kept definitions (defs) and forms, and reports
+// whether the batch is full.
+func (w *batchWriter) add(e *Entry, defs []definition, forms []wordForm) bool {
+	entry := *e
+	entry.Word = strings.ToLower(entry.Word)
+	entry.Pos = strings.ToLower(entry.Pos)
+	w.entries = append(w.entries, stagedEntry{entry: entry, defs: defs, forms: forms})
+	return len(w.entries) >= batchSize
+}
+
+// flush writes the staged entries in a single transaction and clears them. On
+// error, including ctx being cancelled before the transaction is committed, the
+// transaction is rolled back, so none of the batch is written. The checkpoint
+// of the writer's import run, if any, is set to checkpoint, the number of
+// source records read, in the same transaction, even if no entries are staged.
+func (w *batchWriter) flush(ctx context.Context, checkpoint int64) (stats batchStats, err error) {
+	if len(w.entries) == 0 && w.runID == 0 {
+		return batchStats{}, nil
+	}
+
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		return batchStats{}, err
+	}
+	defer tx.Rollback() // No-op once committed
+
+	// IDs created in the transaction are only cached once it is committed
+	newPosIDs := make(map[string]int32)
+	newTagIDs := make(map[string]int32)
+	q := database.New(tx)
+	stats, err = w.write(ctx, q, newPosIDs, newTagIDs)
+	if err != nil {
+		return batchStats{}, err
+	}
+	if w.runID != 0 {
+		err := q.UpdateImportRunCheckpoint(ctx, database.UpdateImportRunCheckpointParams{
+			ID:         w.runID,
+			Checkpoint: checkpoint,
+		})
+		if err != nil {
+			return batchStats{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return batchStats{}, err
+	}
+
+	maps.Copy(w.posIDs, newPosIDs)
+	maps.Copy(w.tagIDs, newTagIDs)
+	w.entries = w.entries[:0]
+	return stats, nil
+}
+
+type wordKey struct {
+	word string
+	lang string
+}
+
+type defKey struct {
+	wordID          int32
+	posID           int32
+	etymologyNumber int32
+}
+
+// write adds the staged entries through q. Each entry gets the same treatment
+// it would get on its own:
+//   - The word is created in the "words" table if it does not exist, and
+//     linked to the writer's import run through the "word_import_runs" table,
+//     noting whether the run added it.
+//   - Its pronunciations and syllables are added to the "pronunciations" and
+//     "hyphenations" tables.
+//   - Links to its inflections or lemmas are added to the "word_forms" table.
+//   - Its definitions are added to the "definitions" table, in the order of
+//     their sense index, linked to the import run and, through the
+//     "definition_tags" table, to their sense tags, unless definitions for the
+//     word, pos, etymology number triple already exist, in the database or
+//     earlier in the batch.
+//   - If its definitions are added, so are their synsets, linking the word to
+//     them, along with their relations (see addSynsets).
+//
+// An entry counts as added if its definitions are, or if it has none and its
+// word is new.
+func (w *batchWriter) write(ctx context.Context, q *database.Queries, newPosIDs, newTagIDs map[string]int32) (batchStats, error) {
+	if len(w.entries) == 0 {
+		return batchStats{}, nil
+	}
+
+	// Get or create the words of every entry
+	words := database.UpsertWordsParams{}
+	seenWords := make(map[wordKey]bool)
+	for _, s := range w.entries {
+		key := wordKey{s.entry.Word, s.entry.Lang}
+		if !seenWords[key] {
+			seenWords[key] = true
+			words.Words = append(words.Words, key.word)
+			words.Langs = append(words.Langs, key.lang)
+		}
+	}
+	wordRows, err := q.UpsertWords(ctx, words)
+	if err != nil {
+		return batchStats{}, err
+	}
+	wordIDs := make(map[wordKey]int32)
+	created := make(map[wordKey]bool)
+	for _, row := range wordRows {
+		key := wordKey{row.Word, row.Lang}
+		wordIDs[key] = row.ID
+		created[key] = row.Created
+	}
+	if w.runID != 0 {
+		wordRuns := database.AddWordImportRunsParams{ImportRunID: w.runID}
+		for _, row := range wordRows {
+			wordRuns.WordIds = append(wordRuns.WordIds, row.ID)
+			wordRuns.Added = append(wordRuns.Added, row.Created)
+		}
+		if err := q.AddWordImportRuns(ctx, wordRuns); err != nil {
+			return batchStats{}, err
+		}
+	}
+
+	// Entries without definitions or forms, e.g. from word lists, need no pos
+	entryWordIDs := make([]int32, len(w.entries))
+	entryPosIDs := make([]int32, len(w.entries))
+	for i, s := range w.entries {
+		entryWordIDs[i] = wordIDs[wordKey{s.entry.Word, s.entry.Lang}]
+		if len(s.defs) == 0 && len(s.forms) == 0 {
+			continue
+		}
+		entryPosIDs[i], err = w.posID(ctx, q, s.entry.Pos, newPosIDs)
+		if err != nil {
+			return batchStats{}, err
+		}
+	}
+
+	if err := w.addSounds(ctx, q, entryWordIDs); err != nil {
+		return batchStats{}, err
+	}
+	if err := w.addForms(ctx, q, entryPosIDs); err != nil {
+		return batchStats{}, err
+	}
+	// Find the entries whose definitions already exist
+	existingKeys := database.GetExistingDefinitionKeysParams{}
+	for i, s := range w.entries {
+		if len(s.defs) > 0 {
+			existingKeys.WordIds = append(existingKeys.WordIds, entryWordIDs[i])
+			existingKeys.PosIds = append(existingKeys.PosIds, entryPosIDs[i])
+			existingKeys.EtymologyNumbers = append(existingKeys.EtymologyNumbers, int32(s.entry.EtymologyNumber))
+		}
+	}
+	existing := make(map[defKey]bool)
+	if len(existingKeys.WordIds) > 0 {
+		rows, err := q.GetExistingDefinitionKeys(ctx, existingKeys)
+		if err != nil {
+			return batchStats{}, err
+		}
+		for _, row := range rows {
+			existing[defKey{row.WordID, row.PosID, row.EtymologyNumber}] = true
+		}
+	}
+
+	// Stage the definitions of the remaining entries, and add only their synsets
+	stats, staged := w.stageDefinitions(entryWordIDs, entryPosIDs, created, existing)
+	if err := w.addSynsets(ctx, q, entryWordIDs, entryPosIDs, staged.written); err != nil {
+		return batchStats{}, err
+	}
+	defs := staged.params
+	defs.ImportRunID = sql.NullInt32{Int32: w.runID, Valid: w.runID != 0}
+	if len(defs.WordIds) == 0 {
+		return stats, nil
+	}
+	defRows, err := q.CreateDefinitions(ctx, defs)
+	if err != nil {
+		return batchStats{}, err
+	}
+
+	// Link the new definitions to their tags
+	for _, tags := range staged.senseTags {
+		for _, tag := range tags {
+			if _, ok := w.tagIDs[tag]; !ok {
+				newTagIDs[tag] = 0
+			}
+		}
+	}
+	if err := w.createTags(ctx, q, newTagIDs); err != nil {
+		return batchStats{}, err
+	}
+	defTags := w.definitionTags(defRows, staged.senseTags, newTagIDs)
+	if len(defTags.DefinitionIds) > 0 {
+		if err := q.AddDefinitionTags(ctx, defTags); err != nil {
+			return batchStats{}, err
+		}
+	}
+	return stats, nil
+}
+
+// senseKey identifies a definition by its sense index among the definitions of
+// its word, pos, etymology number triple.
+type senseKey struct {
+	defKey
+	senseIndex int32
+}
+
+// stagedDefinitions are the definitions of a batch to create.
+type stagedDefinitions struct {
+	params    database.CreateDefinitionsParams
+	senseTags map[senseKey][]string
+	written   []bool // Whether the definitions of each staged entry are created
+}
+
+// stageDefinitions counts the staged entries as added or dupes and returns the
+// definitions to create, with the sense tags of each. entryWordIDs and
+// entryPosIDs hold the word and pos IDs of each entry, created whether each
+// word was just created and existing the triples whose definitions are already
+// in the database. Both maps are updated as entries are staged, so that only
+// the first entry of a word or triple in the batch counts as added.
+func (w *batchWriter) stageDefinitions(entryWordIDs, entryPosIDs []int32, created map[wordKey]bool, existing map[defKey]bool) (batchStats, stagedDefinitions) {
+	stats := batchStats{}
+	staged := stagedDefinitions{
+		senseTags: make(map[senseKey][]string),
+		written:   make([]bool, len(w.entries)),
+	}
+	defs := &staged.params
+	for i, s := range w.entries {
+		word := wordKey{s.entry.Word, s.entry.Lang}
+		if len(s.defs) == 0 {
+			if created[word] {
+				stats.added++
+			} else {
+				stats.dupes++
+			}
+			created[word] = false
+			continue
+		}
+		created[word] = false
+
+		key := defKey{entryWordIDs[i], entryPosIDs[i], int32(s.entry.EtymologyNumber)}
+		if existing[key] {
+			stats.dupes++
+			continue
+		}
+		existing[key] = true
+		staged.written[i] = true
+		stats.added++
+		stats.defs += len(s.defs)
+
+		for _, def := range s.defs {
+			defs.WordIds = append(defs.WordIds, key.wordID)
+			defs.PosIds = append(defs.PosIds, key.posID)
+			defs.Definitions = append(defs.Definitions, def.gloss)
+			defs.EtymologyNumbers = append(defs.EtymologyNumbers, key.etymologyNumber)
+			defs.SenseIndexes = append(defs.SenseIndexes, int32(def.senseIndex))
+			if len(def.tags) > 0 {
+				staged.senseTags[senseKey{key, int32(def.senseIndex)}] = def.tags
+			}
+		}
+	}
+	return stats, staged
+}
+
+// definitionTags links each created definition in defRows to the IDs of its
+// sense tags, taken from the cache or, for tags new to the batch, newTagIDs.
+func (w *batchWriter) definitionTags(defRows []database.CreateDefinitionsRow, senseTags map[senseKey][]string, newTagIDs map[string]int32) database.AddDefinitionTagsParams {
+	defTags := database.AddDefinitionTagsParams{}
+	for _, row := range defRows {
+		for _, tag := range senseTags[senseKey{defKey{row.WordID, row.PosID, row.EtymologyNumber}, row.SenseIndex}] {
+			tagID, ok := w.tagIDs[tag]
+			if !ok {
+				tagID = newTagIDs[tag]
+			}
+			defTags.DefinitionIds = append(defTags.DefinitionIds, row.ID)
+			defTags.TagIds = append(defTags.TagIds, tagID)
+		}
+	}
+	return defTags
+}
+
+// posID returns the ID of pos from the cache, or finds or creates it in the
+// "parts_of_speech" table, recording it in newPosIDs.
+func (w *batchWriter) posID(ctx context.Context, q *database.Queries, pos string, newPosIDs map[string]int32) (int32, error) {
+	if id, ok := w.posIDs[pos]; ok {
+		return id, nil
+	}
+	if id, ok := newPosIDs[pos]; ok {
+		return id, nil
+	}
+
+	// Attempt to find existing entry in pos
+	posID, err := q.GetIDByPos(ctx, pos)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, err
+		}
+	}
+	// Add pos if not found
+	if posID == 0 {
+		dbPos, err := q.CreatePos(ctx, pos)
+		if err != nil {
+			return 0, err
+		}
+		posID = dbPos.ID
+	}
+	newPosIDs[pos] = posID
+	return posID, nil
+}
+
+// createTags gets or creates the tags in the "tags" table whose keys in
+// newTagIDs have no ID yet, setting their IDs.
+func (w *batchWriter) createTags(ctx context.Context, q *database.Queries, newTagIDs map[string]int32) error {
+	missing := []string{}
+	for tag, id := range newTagIDs {
+		if id == 0 {
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	rows, err := q.UpsertTags(ctx, database.UpsertTagsParams{Tags: missing})
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		newTagIDs[row.Tag] = row.ID
+	}
+	return nil
+}
+
+// addSounds adds the pronunciations and syllables of the staged entries, whose
+// words have the IDs wordIDs. Pronunciations that already exist are ignored, as
+// are hyphenations of words that already have one.
+func (w *batchWriter) addSounds(ctx context.Context, q *database.Queries, wordIDs []int32) error {
+	prons := database.AddPronunciationsParams{}
+	hyphens := database.AddHyphenationsParams{}
+	for i, s := range w.entries {
+		for _, sound := range s.entry.Sounds {
+			if sound.IPA == "" && sound.Rhyme == "" && sound.Audio == "" {
+				continue
+			}
+			prons.WordIds = append(prons.WordIds, wordIDs[i])
+			prons.Ipas = append(prons.Ipas, sound.IPA)
+			prons.Rhymes = append(prons.Rhymes, sound.Rhyme)
+			prons.Audios = append(prons.Audios, sound.Audio)
+			prons.Tags = append(prons.Tags, strings.Join(sound.Tags, arraySeparator))
+		}
+		if len(s.entry.Syllables) > 0 {
+			hyphens.WordIds = append(hyphens.WordIds, wordIDs[i])
+			hyphens.Hyphenations = append(hyphens.Hyphenations, strings.Join(s.entry.Syllables, HyphenationSeparator))
+			hyphens.Syllables = append(hyphens.Syllables, int32(len(s.entry.Syllables)))
+		}
+	}
+
+	if len(prons.WordIds) > 0 {
+		if err := q.AddPronunciations(ctx, prons); err != nil {
+			return err
+		}
+	}
+	if len(hyphens.WordIds) > 0 {
+		return q.AddHyphenations(ctx, hyphens)
+	}
+	return nil
+}
+
+// addForms adds the links between the words of the staged entries, whose parts
+// of speech have the IDs posIDs, and their inflections or lemmas. Links that
+// already exist are ignored.
+func (w *batchWriter) addForms(ctx context.Context, q *database.Queries, posIDs []int32) error {
+	forms := database.AddWordFormsParams{}
+	for i, s := range w.entries {
+		for _, form := range s.forms {
+			forms.Forms = append(forms.Forms, form.form)
+			forms.Lemmas = append(forms.Lemmas, form.lemma)
+			forms.Langs = append(forms.Langs, s.entry.Lang)
+			forms.PosIds = append(forms.PosIds, posIDs[i])
+			forms.Tags = append(forms.Tags, strings.Join(form.tags, arraySeparator))
+		}
+	}
+	if len(forms.Forms) == 0 {
+		return nil
+	}
+	return q.AddWordForms(ctx, forms)
+}
+
+// addSynsets adds the synset of each staged definition that has one to the
+// "synsets" table, with the definition's gloss and its entry's part of speech,
+// and links the entry's word to it through the "word_senses" table. Relations
+// of the synsets and definitions are added to the "synset_relations" and
+// "sense_relations" tables. Existing synsets, senses and relations are ignored.
+// wordIDs and posIDs hold the IDs of the staged entries' words and parts of
+// speech, and written whether their definitions are created; the synsets of
+// other entries are left out.
+func (w *batchWriter) addSynsets(ctx context.Context, q *database.Queries, wordIDs, posIDs []int32, written []bool) error {
+	synsets := database.AddSynsetsParams{}
+	senses := database.AddWordSensesParams{}
+	synsetRels := database.AddSynsetRelationsParams{}
+	senseRels := database.AddSenseRelationsParams{}
+	for i, s := range w.entries {
+		if !written[i] {
+			continue
+		}
+		for _, def := range s.defs {
+			if def.key == "" || def.synset == nil {
+				continue
+			}
+			synsets.SynsetKeys = append(synsets.SynsetKeys, def.synset.Key)
+			synsets.PosIds = append(synsets.PosIds, posIDs[i])
+			synsets.Definitions = append(synsets.Definitions, def.gloss)
+
+			senses.SenseKeys = append(senses.SenseKeys, def.key)
+			senses.WordIds = append(senses.WordIds, wordIDs[i])
+			senses.SynsetKeys = append(senses.SynsetKeys, def.synset.Key)
+
+			for _, rel := range def.synset.Relations {
+				synsetRels.SynsetKeys = append(synsetRels.SynsetKeys, def.synset.Key)
+				synsetRels.TargetKeys = append(synsetRels.TargetKeys, rel.Target)
+				synsetRels.RelTypes = append(synsetRels.RelTypes, rel.Type)
+			}
+			for _, rel := range def.relations {
+				senseRels.SenseKeys = append(senseRels.SenseKeys, def.key)
+				senseRels.TargetKeys = append(senseRels.TargetKeys, rel.Target)
+				senseRels.RelTypes = append(senseRels.RelTypes, rel.Type)
+			}
+		}
+	}
+	if len(synsets.SynsetKeys) == 0 {
+		return nil
+	}
+
+	// Synsets must exist before the senses referring to them
+	if err := q.AddSynsets(ctx, synsets); err != nil {
+		return err
+	}
+	if err := q.AddWordSenses(ctx, senses); err != nil {
+		return err
+	}
+	if len(synsetRels.SynsetKeys) > 0 {
+		if err := q.AddSynsetRelations(ctx, synsetRels); err != nil {
+			return err
+		}
+	}
+	if len(senseRels.SenseKeys) > 0 {
+		return q.AddSenseRelations(ctx, senseRels)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package extract
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pbojar/dictextract/internal/database"
+)
+
+func TestStageDefinitions(t *testing.T) {
+	w := newBatchWriter(nil, 0)
+	staged := []struct {
+		word   string
+		etym   int
+		defs   []definition
+		wordID int32
+	}{
+		{"Cat", 0, []definition{{gloss: "A feline.", tags: []string{"common"}}, {gloss: "A musician.", senseIndex: 2}}, 1},
+		{"cat", 0, []definition{{gloss: "A feline again."}}, 1}, // Dupe earlier in the batch
+		{"cat", 1, []definition{{gloss: "To hoist."}}, 1},       // Other etymology
+		{"dog", 0, []definition{{gloss: "A canine."}}, 2},       // Dupe in the database
+		{"cats", 0, nil, 3}, // New word
+		{"cats", 0, nil, 3}, // Dupe of a new word
+		{"dog", 0, nil, 2},  // Existing word
+		{"bird", 0, []definition{{gloss: "A flier."}}, 4},
+		{"bird", 0, nil, 4}, // Word added with definitions earlier in the batch
+	}
+	const nounID = 10
+	var wordIDs, posIDs []int32
+	for _, s := range staged {
+		w.add(&Entry{Word: s.word, Lang: "en", Pos: "Noun", EtymologyNumber: s.etym}, s.defs, nil)
+		wordIDs = append(wordIDs, s.wordID)
+		posID := int32(0)
+		if len(s.defs) > 0 {
+			posID = nounID
+		}
+		posIDs = append(posIDs, posID)
+	}
+	created := map[wordKey]bool{{"cat", "en"}: true, {"dog", "en"}: false, {"cats", "en"}: true, {"bird", "en"}: true}
+	existing := map[defKey]bool{{2, nounID, 0}: true}
+
+	stats, got := w.stageDefinitions(wordIDs, posIDs, created, existing)
+	defs, senseTags := got.params, got.senseTags
+	if want := (batchStats{added: 4, dupes: 5, defs: 4}); stats != want {
+		t.Errorf("stats = %+v, want %+v", stats, want)
+	}
+	if want := []string{"A feline.", "A musician.", "To hoist.", "A flier."}; !slices.Equal(defs.Definitions, want) {
+		t.Errorf("definitions = %v, want %v", defs.Definitions, want)
+	}
+	if want := []int32{1, 1, 1, 4}; !slices.Equal(defs.WordIds, want) {
+		t.Errorf("word IDs = %v, want %v", defs.WordIds, want)
+	}
+	if want := []int32{0, 0, 1, 0}; !slices.Equal(defs.EtymologyNumbers, want) {
+		t.Errorf("etymology numbers = %v, want %v", defs.EtymologyNumbers, want)
+	}
+	if want := []int32{0, 2, 0, 0}; !slices.Equal(defs.SenseIndexes, want) {
+		t.Errorf("sense indexes = %v, want %v", defs.SenseIndexes, want)
+	}
+	if len(senseTags) != 1 || !slices.Equal(senseTags[senseKey{defKey{1, nounID, 0}, 0}], []string{"common"}) {
+		t.Errorf("sense tags = %v, want only [common] for the first sense of cat", senseTags)
+	}
+	if want := []bool{true, false, true, false, false, false, false, true, false}; !slices.Equal(got.written, want) {
+		t.Errorf("written = %v, want %v", got.written, want)
+	}
+	if !existing[defKey{1, nounID, 1}] || created[wordKey{"cats", "en"}] {
+		t.Errorf("stageDefinitions() did not record the staged words and definitions")
+	}
+}
+
+func TestDefinitionTags(t *testing.T) {
+	w := newBatchWriter(nil, 0)
+	w.tagIDs["common"] = 5
+	newTagIDs := map[string]int32{"slang": 7}
+
+	key := defKey{1, 10, 0}
+	senseTags := map[senseKey][]string{
+		{key, 0}: {"common", "slang"},
+		{key, 3}: {"slang"},
+	}
+	rows := []database.CreateDefinitionsRow{
+		{ID: 100, WordID: 1, PosID: 10, SenseIndex: 2},
+		{ID: 101, WordID: 1, PosID: 10, SenseIndex: 3},
+		{ID: 102, WordID: 1, PosID: 10, SenseIndex: 0},
+		{ID: 103, WordID: 2, PosID: 10, SenseIndex: 0},
+	}
+	got := w.definitionTags(rows, senseTags, newTagIDs)
+	if want := []int32{101, 102, 102}; !slices.Equal(got.DefinitionIds, want) {
+		t.Errorf("definition IDs = %v, want %v", got.DefinitionIds, want)
+	}
+	if want := []int32{7, 5, 7}; !slices.Equal(got.TagIds, want) {
+		t.Errorf("tag IDs = %v, want %v", got.TagIds, want)
+	}
+}
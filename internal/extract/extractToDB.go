@@ -0,0 +1,135 @@
+package extract
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"runtime"
+
+	"github.com/pbojar/dictextract/internal/database"
+)
+
+// ToDB reads every entry of src and filters it by applying filterCfg with the alphabet of its language, which must be
+// one of langs. Every kept sense of a kept entry is added to the database db along with its sense index, etymology
+// number and sense tags. Links between the entry's word and its inflections or lemmas, and the word's pronunciations and
+// syllables, are also added. Kept entries are written in batches, each in its own transaction, so an error leaves the
+// batches before it in the database.
+//
+// Entries are decoded (for a RecordSource) and filtered by the given number of workers, or one per CPU if workers is
+// not positive, while src is read on a goroutine of its own and the batches are written by the caller. Entries are still
+// written in the order of src, so which of several entries for the same word and pos is kept does not depend on workers.
+//
+// If run has an ID, the number of records of src read is checkpointed to that import run with every batch, and the
+// run is marked completed at the end. The first run.Checkpoint records of src are skipped, as already imported.
+//
+// If ctx is cancelled, the batch being staged is committed, or the batch being written is rolled back, and ctx's error
+// is returned after printing how many records of src are imported.
+func ToDB(ctx context.Context, src Source, langs []Language, filterCfg FilterConfig, db *sql.DB, workers int,
+	run ImportRun) (err error) {
+
+	optional, ok := src.(OptionalSensesSource)
+	sensesOptional := ok && optional.SensesOptional()
+	filters := make(map[string]*Filter)
+	for _, lang := range langs {
+		filter, err := NewFilter(filterCfg, lang)
+		if err != nil {
+			return err
+		}
+		filter.sensesOptional = sensesOptional
+		filters[lang.Code] = filter
+	}
+
+	numAdded := 0
+	numFiltered := 0
+	numDupes := 0
+	numDefs := 0
+	numRead := run.Checkpoint
+	numCommitted := run.Checkpoint
+	writer := newBatchWriter(db, run.ID)
+	flush := func(ctx context.Context) error {
+		stats, err := writer.flush(ctx, numRead)
+		if err != nil {
+			return err
+		}
+		numCommitted = numRead
+		numAdded += stats.added
+		numDupes += stats.dupes
+		numDefs += stats.defs
+		fmt.Printf("\033[2K\rEntries (added, filtered, dupes): (%d, %d, %d) Definitions added: %d", numAdded, numFiltered, numDupes, numDefs)
+		return nil
+	}
+	interrupted := func() error {
+		fmt.Printf("The first %d records of the source are imported.\n", numCommitted)
+		return ctx.Err()
+	}
+	// rolledBack returns the error err of flushing a batch, unless it was caused by ctx being cancelled, which rolled
+	// the batch back
+	rolledBack := func(err error) error {
+		if ctx.Err() == nil {
+			return err
+		}
+		fmt.Printf("\nInterrupted! The batch being written was rolled back.\n")
+		return interrupted()
+	}
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	p := startPipeline(src, filters, workers, run.Checkpoint)
+	defer p.stop()
+
+	fmt.Println("Extracting and Adding Definitions...")
+	for {
+		if ctx.Err() != nil {
+			// Commit the entries staged so far rather than read them again on resuming
+			if err := flush(context.WithoutCancel(ctx)); err != nil {
+				return err
+			}
+			fmt.Printf("\nInterrupted! The batch in progress was committed.\n")
+			return interrupted()
+		}
+
+		r, ok := p.nextResult(ctx)
+		if !ok {
+			if ctx.Err() != nil {
+				continue // Interrupted while waiting for the source
+			}
+			break
+		}
+		if r.err != nil {
+			return r.err
+		}
+		numRead = run.Checkpoint + int64(r.seq) + 1
+		if r.skipped {
+			continue
+		}
+		if !r.kept {
+			numFiltered++
+			continue
+		}
+
+		if writer.add(&r.entry, r.defs, r.forms) {
+			if err := flush(ctx); err != nil {
+				return rolledBack(err)
+			}
+		}
+	}
+	if err := flush(ctx); err != nil {
+		return rolledBack(err)
+	}
+	if run.ID != 0 {
+		err := database.New(db).CompleteImportRun(ctx, database.CompleteImportRunParams{
+			ID:         run.ID,
+			Checkpoint: numRead,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("\nExtract and add complete!\n")
+	if numAdded == 0 && numDupes == 0 && numFiltered > 0 {
+		fmt.Printf("Warning: none of the %d entries read were kept by the filter config\n", numFiltered)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package extract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// FilterConfig is a declarative word policy used to build a Filter. It is read
+// from the JSON file referenced by "filter_config_path" in the user config.
+type FilterConfig struct {
+	AllowedPos        []string          `json:"allowed_pos"`         // Accepted parts of speech, all if empty
+	MinLength         int               `json:"min_length"`          // Minimum word length in runes, 0 for none
+	MaxLength         int               `json:"max_length"`          // Maximum word length in runes, 0 for none
+	Alphabets         map[string]string `json:"alphabets"`           // Letters allowed in a word by language code, replacing the language's alphabet
+	InitialismCutoff  int               `json:"initialism_cutoff"`   // Run of capitals marking an initialism, 0 to disable
+	DenyPatterns      []string          `json:"deny_patterns"`       // Regular expressions rejecting words they match
+	GlossDenyKeywords []string          `json:"gloss_deny_keywords"` // Case-insensitive keywords rejecting a definition
+	ExcludeTags       []string          `json:"exclude_tags"`        // Sense tags rejecting a definition
+	RequireDefinition bool              `json:"require_definition"`  // Reject entries without a kept definition
+}
+
+// DefaultFilterConfig returns the word policy used when no filter config is set.
+// It accepts nouns, pronouns, verbs, adjectives, adverbs, prepositions,
+// conjunctions and interjections, and rejects initialisms, acronyms and words
+// without definitions.
+func DefaultFilterConfig() FilterConfig {
+	return FilterConfig{
+		AllowedPos:        []string{"noun", "pron", "verb", "adj", "adv", "prep", "conj", "intj"},
+		InitialismCutoff:  2,
+		GlossDenyKeywords: []string{"initialism", "acronym"},
+		RequireDefinition: true,
+	}
+}
+
+// LoadFilterConfig reads a FilterConfig from the JSON file at path. Fields
+// missing from the file keep their values from DefaultFilterConfig, and unknown
+// fields are an error so typos do not silently change the policy.
+func LoadFilterConfig(path string) (FilterConfig, error) {
+	cfg := DefaultFilterConfig()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("error reading file: %v", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("error unmarshalling JSON: %v", err)
+	}
+
+	if cfg.MinLength < 0 || cfg.MaxLength < 0 || (cfg.MaxLength > 0 && cfg.MinLength > cfg.MaxLength) {
+		return cfg, fmt.Errorf("invalid length bounds: min_length %d, max_length %d", cfg.MinLength, cfg.MaxLength)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,218 @@
+package extract
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// isAlphaOnly returns true if all runes in the string are in the alphabet range table.
+func isAlphaOnly(s string, alphabet *unicode.RangeTable) bool {
+	for _, r := range s {
+		if !unicode.Is(alphabet, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasInitialism returns true if the length of a sequence of capital letters in
+// 's' meets or exceeds the 'cutOff' and false otherwise.
+func hasInitialism(s string, cutOff int, capitals *unicode.RangeTable) bool {
+	count := 0
+	for _, r := range s {
+		if unicode.Is(capitals, r) {
+			count += 1
+		} else {
+			count = 0
+		}
+		if count >= cutOff {
+			return true
+		}
+	}
+	return false
+}
+
+// definition is a sense of an entry that passed the filter.
+type definition struct {
+	senseIndex int // Index of the sense in the entry
+	gloss      string
+	tags       []string
+	lemmas     []string // Words the entry is a form or alternative spelling of in this sense
+	key        string
+	synset     *Synset
+	relations  []Relation
+}
+
+// wordForm links an inflected form to its lemma.
+type wordForm struct {
+	form  string
+	lemma string
+	tags  []string // Never nil, since a nil slice is stored as NULL
+}
+
+// Filter applies a FilterConfig to the entries of one language.
+type Filter struct {
+	cfg            FilterConfig
+	lang           Language
+	denyPatterns   []*regexp.Regexp
+	sensesOptional bool // Keep entries without senses, as read from an OptionalSensesSource
+}
+
+// NewFilter compiles cfg into a Filter for entries in the language lang. If
+// cfg.Alphabets has letters for lang, they replace the alphabet of lang.
+func NewFilter(cfg FilterConfig, lang Language) (*Filter, error) {
+	if letters := cfg.Alphabets[lang.Code]; letters != "" {
+		lang = newLanguage(lang.Code, letters)
+	}
+	f := &Filter{
+		cfg:  cfg,
+		lang: lang,
+	}
+	for _, pattern := range cfg.DenyPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deny pattern '%s': %v", pattern, err)
+		}
+		f.denyPatterns = append(f.denyPatterns, re)
+	}
+	return f, nil
+}
+
+// definitions returns the senses of an entry that are kept by the filter, and
+// whether the entry itself is kept. An entry is only kept if:
+//  1. The language code is that of the filter's language.
+//  2. The part of speech is one of cfg.AllowedPos, or unknown.
+//  3. The word's length is within cfg.MinLength and cfg.MaxLength.
+//  4. The word contains only runes in the filter's alphabet.
+//  5. The word is not an initialism (per hasInitialism with cfg.InitialismCutoff).
+//  6. The word matches none of cfg.DenyPatterns.
+//
+// and a sense of a kept entry is only kept if:
+//  1. It has a gloss.
+//  2. Its gloss contains none of cfg.GlossDenyKeywords.
+//  3. It has none of cfg.ExcludeTags.
+//
+// If cfg.RequireDefinition is set, an entry none of whose senses are kept is not
+// kept either, unless it has no senses and they are optional in its source.
+func (f *Filter) definitions(w *Entry) ([]definition, bool) {
+
+	// Match lang code
+	if w.Lang != f.lang.Code {
+		return nil, false
+	}
+
+	// Ensure word is an accepted part of speech
+	if w.Pos != "" && len(f.cfg.AllowedPos) > 0 && !slices.Contains(f.cfg.AllowedPos, w.Pos) {
+		return nil, false
+	}
+
+	// Check length bounds
+	wordLen := utf8.RuneCountInString(w.Word)
+	if wordLen < f.cfg.MinLength || (f.cfg.MaxLength > 0 && wordLen > f.cfg.MaxLength) {
+		return nil, false
+	}
+
+	// Check that word contains only letters of the language's alphabet
+	if !isAlphaOnly(w.Word, f.lang.Alphabet) {
+		return nil, false
+	}
+
+	// Check if word is an initialism/acronym by checking if it has adjacent CAPS
+	if f.cfg.InitialismCutoff > 0 && hasInitialism(w.Word, f.cfg.InitialismCutoff, f.lang.Capitals) {
+		return nil, false
+	}
+
+	// Check word against deny list
+	for _, re := range f.denyPatterns {
+		if re.MatchString(w.Word) {
+			return nil, false
+		}
+	}
+
+	defs := []definition{}
+	for i, sense := range w.Senses {
+		if def, ok := f.definition(sense); ok {
+			def.senseIndex = i
+			defs = append(defs, def)
+		}
+	}
+	if len(w.Senses) == 0 && f.sensesOptional {
+		return defs, true
+	}
+	return defs, len(defs) > 0 || !f.cfg.RequireDefinition
+}
+
+// definition returns the definition for a sense and whether the sense is kept.
+func (f *Filter) definition(sense Sense) (definition, bool) {
+
+	// Check for definition, invalidate senses without glosses
+	if sense.Gloss == "" {
+		return definition{}, false
+	}
+	gloss := sense.Gloss
+
+	// Check the definition for denied keywords, e.g. initialism/acronym
+	lowerDef := strings.ToLower(gloss)
+	for _, keyword := range f.cfg.GlossDenyKeywords {
+		if strings.Contains(lowerDef, strings.ToLower(keyword)) {
+			return definition{}, false
+		}
+	}
+
+	// Finally, check the definition's tags
+	for _, tag := range sense.Tags {
+		if slices.Contains(f.cfg.ExcludeTags, tag) {
+			return definition{}, false
+		}
+	}
+
+	return definition{
+		gloss:     gloss,
+		tags:      sense.Tags,
+		lemmas:    sense.Lemmas,
+		key:       sense.Key,
+		synset:    sense.Synset,
+		relations: sense.Relations,
+	}, true
+}
+
+// Sense tags that only say a sense links to a lemma.
+var linkTags = []string{"form-of", "alt-of"}
+
+// wordForms returns the links between the word of an entry, which must have been
+// kept by the filter with definitions defs, and its inflections or lemmas. The
+// entry's forms are inflections of its word, while the lemmas of its kept senses
+// are words its word is a form of. Forms that are not valid words in the
+// filter's alphabet are left out.
+func (f *Filter) wordForms(w *Entry, defs []definition) []wordForm {
+	word := strings.ToLower(w.Word)
+	forms := []wordForm{}
+	for _, form := range w.Forms {
+		if !isAlphaOnly(form.Form, f.lang.Alphabet) || strings.ToLower(form.Form) == word {
+			continue
+		}
+		forms = append(forms, wordForm{
+			form:  strings.ToLower(form.Form),
+			lemma: word,
+			tags:  append([]string{}, form.Tags...),
+		})
+	}
+	for _, def := range defs {
+		for _, lemma := range def.lemmas {
+			if !isAlphaOnly(lemma, f.lang.Alphabet) || strings.ToLower(lemma) == word {
+				continue
+			}
+			tags := slices.DeleteFunc(append([]string{}, def.tags...), func(tag string) bool { return slices.Contains(linkTags, tag) })
+			forms = append(forms, wordForm{
+				form:  word,
+				lemma: strings.ToLower(lemma),
+				tags:  tags,
+			})
+		}
+	}
+	return forms
+}
@@ -0,0 +1,169 @@
+package extract
+
+import (
+	"slices"
+	"testing"
+)
+
+// testFilter returns a Filter for the language code with cfg, failing the test
+// on error.
+func testFilter(t *testing.T, cfg FilterConfig, code string) *Filter {
+	t.Helper()
+	lang, err := LookupLanguage(code, nil)
+	if err != nil {
+		t.Fatalf("LookupLanguage(%q) error = %v", code, err)
+	}
+	f, err := NewFilter(cfg, lang)
+	if err != nil {
+		t.Fatalf("NewFilter() error = %v", err)
+	}
+	return f
+}
+
+func TestFilterEntries(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	cfg.MinLength = 2
+	cfg.MaxLength = 6
+	cfg.DenyPatterns = []string{"^zz"}
+	f := testFilter(t, cfg, "en")
+
+	gloss := []Sense{{Gloss: "a gloss"}}
+	tests := []struct {
+		name  string
+		entry Entry
+		want  bool
+	}{
+		{"kept", Entry{Word: "cat", Lang: "en", Pos: "noun", Senses: gloss}, true},
+		{"unknown pos", Entry{Word: "cat", Lang: "en", Senses: gloss}, true},
+		{"other language", Entry{Word: "chat", Lang: "fr", Pos: "noun", Senses: gloss}, false},
+		{"pos not allowed", Entry{Word: "cat", Lang: "en", Pos: "name", Senses: gloss}, false},
+		{"too short", Entry{Word: "a", Lang: "en", Pos: "noun", Senses: gloss}, false},
+		{"too long", Entry{Word: "catalog", Lang: "en", Pos: "noun", Senses: gloss}, false},
+		{"not in alphabet", Entry{Word: "café", Lang: "en", Pos: "noun", Senses: gloss}, false},
+		{"capitalized", Entry{Word: "Paris", Lang: "en", Pos: "noun", Senses: gloss}, true},
+		{"initialism", Entry{Word: "NASA", Lang: "en", Pos: "noun", Senses: gloss}, false},
+		{"denied pattern", Entry{Word: "zzz", Lang: "en", Pos: "intj", Senses: gloss}, false},
+		{"no senses", Entry{Word: "cat", Lang: "en", Pos: "noun"}, false},
+		{"no kept senses", Entry{Word: "cat", Lang: "en", Pos: "noun", Senses: []Sense{{Gloss: "an acronym"}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := f.definitions(&tt.entry); got != tt.want {
+				t.Errorf("definitions(%+v) kept = %v, want %v", tt.entry, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterSenses(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	cfg.ExcludeTags = []string{"obsolete"}
+	f := testFilter(t, cfg, "en")
+
+	entry := Entry{Word: "cat", Lang: "en", Pos: "noun", Senses: []Sense{
+		{Gloss: "A small feline.", Tags: []string{"common"}},
+		{Gloss: ""},
+		{Gloss: "An ACRONYM of something."},
+		{Gloss: "A whip.", Tags: []string{"obsolete"}},
+		{Gloss: "A jazz musician."},
+	}}
+	defs, kept := f.definitions(&entry)
+	if !kept {
+		t.Fatalf("definitions() did not keep the entry")
+	}
+	got := []int{}
+	for _, def := range defs {
+		got = append(got, def.senseIndex)
+	}
+	if want := []int{0, 4}; !slices.Equal(got, want) {
+		t.Errorf("kept sense indexes %v, want %v", got, want)
+	}
+	if !slices.Equal(defs[0].tags, []string{"common"}) {
+		t.Errorf("tags of first definition = %v, want [common]", defs[0].tags)
+	}
+}
+
+func TestFilterRequireDefinition(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	cfg.RequireDefinition = false
+	f := testFilter(t, cfg, "en")
+
+	entry := Entry{Word: "cat", Lang: "en"}
+	if defs, kept := f.definitions(&entry); !kept || len(defs) != 0 {
+		t.Errorf("definitions() = %v, %v, want no definitions and kept", defs, kept)
+	}
+}
+
+func TestFilterSensesOptional(t *testing.T) {
+	f := testFilter(t, DefaultFilterConfig(), "en")
+	f.sensesOptional = true
+
+	tests := []struct {
+		name  string
+		entry Entry
+		want  bool
+	}{
+		{"no senses", Entry{Word: "cat", Lang: "en"}, true},
+		{"no kept senses", Entry{Word: "cat", Lang: "en", Senses: []Sense{{Gloss: "an acronym"}}}, false},
+		{"rejected word", Entry{Word: "NASA", Lang: "en"}, false},
+		{"proper noun", Entry{Word: "Paris", Lang: "en", Pos: "name"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := f.definitions(&tt.entry); got != tt.want {
+				t.Errorf("definitions(%+v) kept = %v, want %v", tt.entry, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterAlphabets(t *testing.T) {
+	cfg := DefaultFilterConfig()
+	cfg.Alphabets = map[string]string{"en": "abc"}
+	en := testFilter(t, cfg, "en")
+	pl := testFilter(t, cfg, "pl")
+
+	gloss := []Sense{{Gloss: "a gloss"}}
+	tests := []struct {
+		name   string
+		filter *Filter
+		entry  Entry
+		want   bool
+	}{
+		{"replaced alphabet", en, Entry{Word: "cab", Lang: "en", Senses: gloss}, true},
+		{"outside replaced alphabet", en, Entry{Word: "cat", Lang: "en", Senses: gloss}, false},
+		{"other language keeps its alphabet", pl, Entry{Word: "żółw", Lang: "pl", Senses: gloss}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := tt.filter.definitions(&tt.entry); got != tt.want {
+				t.Errorf("definitions(%+v) kept = %v, want %v", tt.entry, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterWordForms(t *testing.T) {
+	f := testFilter(t, DefaultFilterConfig(), "en")
+
+	entry := Entry{
+		Word:  "Cat",
+		Lang:  "en",
+		Pos:   "noun",
+		Forms: []Form{{Form: "cats", Tags: []string{"plural"}}, {Form: "cat"}, {Form: "cat's"}},
+	}
+	defs := []definition{{gloss: "plural of cot", tags: []string{"form-of", "plural"}, lemmas: []string{"cot"}}}
+	got := f.wordForms(&entry, defs)
+	want := []wordForm{
+		{form: "cats", lemma: "cat", tags: []string{"plural"}},
+		{form: "cat", lemma: "cot", tags: []string{"plural"}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("wordForms() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].form != want[i].form || got[i].lemma != want[i].lemma || !slices.Equal(got[i].tags, want[i].tags) {
+			t.Errorf("wordForms()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
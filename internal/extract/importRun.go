@@ -0,0 +1,41 @@
+package extract
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+)
+
+// ImportRun identifies the row of the "import_runs" table recording an import
+// by ToDB, and holds the number of records of its source imported by an
+// earlier, interrupted attempt. The zero ImportRun records nothing and skips
+// nothing.
+type ImportRun struct {
+	ID         int32
+	Checkpoint int64
+}
+
+// HashFiles returns the hex encoded SHA-256 of the files at paths, read one
+// after the other, identifying the raw files of an import run. The hash of a
+// single file is that of its content.
+func HashFiles(paths ...string) (string, error) {
+	h := sha256.New()
+	for _, path := range paths {
+		if err := hashFile(h, path); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(w, file)
+	return err
+}
@@ -0,0 +1,49 @@
+package extract
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashFiles(t *testing.T) {
+	dir := t.TempDir()
+	dic := filepath.Join(dir, "en.dic")
+	aff := filepath.Join(dir, "en.aff")
+	if err := os.WriteFile(dic, []byte("1\ncat/S\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(aff, []byte("SFX S Y 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	single, err := HashFiles(dic)
+	if err != nil {
+		t.Fatalf("HashFiles() error = %v", err)
+	}
+	sum := sha256.Sum256([]byte("1\ncat/S\n"))
+	if want := hex.EncodeToString(sum[:]); single != want {
+		t.Errorf("HashFiles(dic) = %s, want the hash of its content %s", single, want)
+	}
+
+	both, err := HashFiles(dic, aff)
+	if err != nil {
+		t.Fatalf("HashFiles() error = %v", err)
+	}
+	if err := os.WriteFile(aff, []byte("SFX S Y 2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := HashFiles(dic, aff)
+	if err != nil {
+		t.Fatalf("HashFiles() error = %v", err)
+	}
+	if both == single || changed == both {
+		t.Errorf("HashFiles(dic, aff) = %s, then %s after changing aff, want hashes of both files", both, changed)
+	}
+
+	if _, err := HashFiles(dic, filepath.Join(dir, "missing.aff")); err == nil {
+		t.Errorf("HashFiles() with a missing file did not fail")
+	}
+}
@@ -0,0 +1,93 @@
+package extract
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Language holds the alphabet used to filter the words of one language.
+type Language struct {
+	Code     string              // Language code as used by Wiktionary, e.g. "en"
+	Alphabet *unicode.RangeTable // Letters allowed in a word, in both cases
+	Capitals *unicode.RangeTable // Capital letters, used to detect initialisms
+}
+
+// latinLetters is the basic Latin alphabet, included in every language so
+// loanwords are kept.
+const latinLetters = "abcdefghijklmnopqrstuvwxyz"
+
+// languages maps language codes to their alphabets.
+var languages = map[string]Language{
+	"en": newLanguage("en", latinLetters),
+	"fr": newLanguage("fr", latinLetters+"àâæçéèêëîïôœùûüÿ"),
+	"es": newLanguage("es", latinLetters+"áéíñóúü"),
+	"de": newLanguage("de", latinLetters+"äöüß"),
+	"pl": newLanguage("pl", latinLetters+"ąćęłńóśźż"),
+}
+
+// LookupLanguage returns the Language for a Wiktionary language code. If
+// alphabets, as in FilterConfig.Alphabets, has letters for code, they are its
+// alphabet, so any language can be given one; otherwise code must be one of the
+// built-in languages.
+func LookupLanguage(code string, alphabets map[string]string) (Language, error) {
+	if letters := alphabets[code]; letters != "" {
+		return newLanguage(code, letters), nil
+	}
+	lang, ok := languages[code]
+	if !ok {
+		codes := make([]string, 0, len(languages))
+		for c := range languages {
+			codes = append(codes, c)
+		}
+		sort.Strings(codes)
+		return Language{}, fmt.Errorf("unsupported language code '%s', expected one of %v or one with an alphabet in the filter config", code, codes)
+	}
+	return lang, nil
+}
+
+// newLanguage creates a Language whose alphabet is the lowercase letters in
+// letters together with their capitals.
+func newLanguage(code, letters string) Language {
+	alphabet := []rune{}
+	capitals := []rune{}
+	for _, r := range strings.ToLower(letters) {
+		if slices.Contains(alphabet, r) {
+			continue
+		}
+		alphabet = append(alphabet, r)
+		// Some letters, like ß, have no single rune capital
+		if upper := unicode.ToUpper(r); upper != r && unicode.IsUpper(upper) {
+			alphabet = append(alphabet, upper)
+			capitals = append(capitals, upper)
+		}
+	}
+	return Language{
+		Code:     code,
+		Alphabet: rangeTable(alphabet),
+		Capitals: rangeTable(capitals),
+	}
+}
+
+// rangeTable builds a unicode.RangeTable holding the given runes.
+func rangeTable(runes []rune) *unicode.RangeTable {
+	table := &unicode.RangeTable{}
+	for _, r := range runes {
+		if r > 0xFFFF {
+			table.R32 = append(table.R32, unicode.Range32{Lo: uint32(r), Hi: uint32(r), Stride: 1})
+			continue
+		}
+		table.R16 = append(table.R16, unicode.Range16{Lo: uint16(r), Hi: uint16(r), Stride: 1})
+	}
+
+	// unicode.Is requires ranges sorted by Lo
+	sort.Slice(table.R16, func(i, j int) bool {
+		return table.R16[i].Lo < table.R16[j].Lo
+	})
+	sort.Slice(table.R32, func(i, j int) bool {
+		return table.R32[i].Lo < table.R32[j].Lo
+	})
+	return table
+}
@@ -0,0 +1,49 @@
+package extract
+
+import (
+	"testing"
+	"unicode"
+)
+
+func TestLookupLanguage(t *testing.T) {
+	alphabets := map[string]string{"it": "abcdefghilmnopqrstuvzàèéìòù", "en": "abc"}
+	tests := []struct {
+		name      string
+		code      string
+		alphabets map[string]string
+		in        []rune // Letters of the alphabet
+		out       []rune // Letters left out of it
+		wantErr   bool
+	}{
+		{"built-in", "pl", nil, []rune("aząŻ"), []rune("éß"), false},
+		{"built-in without config alphabet", "de", alphabets, []rune("ßÄ"), []rune("é"), false},
+		{"config alphabet", "it", alphabets, []rune("aùÈ"), []rune("jk"), false},
+		{"config alphabet replaces built-in", "en", alphabets, []rune("abC"), []rune("d"), false},
+		{"unknown", "it", nil, nil, nil, true},
+		{"unknown with other alphabets", "nl", alphabets, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang, err := LookupLanguage(tt.code, tt.alphabets)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LookupLanguage(%q) error = %v, wantErr %v", tt.code, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if lang.Code != tt.code {
+				t.Errorf("Code = %q, want %q", lang.Code, tt.code)
+			}
+			for _, r := range tt.in {
+				if !unicode.Is(lang.Alphabet, r) {
+					t.Errorf("alphabet of %s does not have %q", tt.code, r)
+				}
+			}
+			for _, r := range tt.out {
+				if unicode.Is(lang.Alphabet, r) {
+					t.Errorf("alphabet of %s has %q", tt.code, r)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package extract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// job is a record or entry read from a Source, numbered by its position in the
+// source so results can be put back in order.
+type job struct {
+	seq    int
+	record []byte // Set if read from a RecordSource
+	entry  Entry  // Set otherwise
+	err    error  // Error reading the source, ending it
+}
+
+// result is a job after decoding and filtering.
+type result struct {
+	seq     int
+	entry   Entry
+	defs    []definition
+	forms   []wordForm
+	skipped bool // The record could not be decoded
+	kept    bool // The entry passed its language's filter
+	err     error
+}
+
+// pipeline reads a Source on one goroutine and decodes and filters its entries
+// on several workers, delivering the results in source order. The number of
+// jobs in flight is bounded so a slow record cannot make the results waiting
+// behind it pile up.
+type pipeline struct {
+	results  chan result
+	window   chan struct{}  // Holds a token for every job in flight
+	done     chan struct{}  // Closed to stop the goroutines early
+	readDone chan struct{}  // Closed once the source is no longer read
+	wg       sync.WaitGroup // Of the workers
+
+	pending map[int]result // Results received ahead of their turn
+	next    int            // Sequence number of the next result to deliver
+}
+
+// startPipeline starts reading src with the given number of workers, filtering
+// entries with filters, keyed by language code. The first skip records of src
+// are read and dropped, and the rest numbered from 0.
+func startPipeline(src Source, filters map[string]*Filter, workers int, skip int64) *pipeline {
+	p := &pipeline{
+		results:  make(chan result, 2*workers),
+		window:   make(chan struct{}, 16*workers),
+		done:     make(chan struct{}),
+		readDone: make(chan struct{}),
+		pending:  make(map[int]result),
+	}
+	jobs := make(chan job, 2*workers)
+
+	go func() {
+		defer close(p.readDone)
+		defer close(jobs)
+		p.read(src, skip, jobs)
+	}()
+
+	for range workers {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for {
+				var j job
+				var ok bool
+				select {
+				case j, ok = <-jobs:
+					if !ok {
+						return
+					}
+				case <-p.done:
+					return
+				}
+				select {
+				case p.results <- process(src, filters, j):
+				case <-p.done:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		p.wg.Wait()
+		close(p.results)
+	}()
+	return p
+}
+
+// read sends the records or entries of src after the first skip to jobs,
+// stopping after the last one or an error.
+func (p *pipeline) read(src Source, skip int64, jobs chan<- job) {
+	recordSrc, isRecordSource := src.(RecordSource)
+	for range skip {
+		var err error
+		if isRecordSource {
+			_, err = recordSrc.NextRecord()
+		} else {
+			_, err = src.Next()
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = fmt.Errorf("source ended before the %d records to skip", skip)
+			}
+			// Delivered like any job, so it takes a token too
+			select {
+			case p.window <- struct{}{}:
+			case <-p.done:
+				return
+			}
+			select {
+			case jobs <- job{err: err}:
+			case <-p.done:
+			}
+			return
+		}
+		select {
+		case <-p.done:
+			return
+		default:
+		}
+	}
+
+	for seq := 0; ; seq++ {
+		select {
+		case p.window <- struct{}{}:
+		case <-p.done:
+			return
+		}
+		// The source may be closed once the pipeline is stopped
+		select {
+		case <-p.done:
+			return
+		default:
+		}
+
+		j := job{seq: seq}
+		if isRecordSource {
+			j.record, j.err = recordSrc.NextRecord()
+		} else {
+			j.entry, j.err = src.Next()
+		}
+		if errors.Is(j.err, io.EOF) {
+			return
+		}
+
+		select {
+		case jobs <- j:
+		case <-p.done:
+			return
+		}
+		if j.err != nil {
+			return
+		}
+	}
+}
+
+// process decodes the record of j, if any, and filters its entry.
+func process(src Source, filters map[string]*Filter, j job) result {
+	r := result{seq: j.seq, entry: j.entry, err: j.err}
+	if j.err != nil {
+		return r
+	}
+	if recordSrc, ok := src.(RecordSource); ok {
+		r.entry, ok = recordSrc.Decode(j.record)
+		if !ok {
+			r.skipped = true
+			return r
+		}
+	}
+
+	filter, ok := filters[r.entry.Lang]
+	if !ok {
+		return r
+	}
+	r.defs, r.kept = filter.definitions(&r.entry)
+	if r.kept {
+		r.forms = filter.wordForms(&r.entry, r.defs)
+	}
+	return r
+}
+
+// nextResult returns the next result in source order, or false once every
+// result has been delivered or ctx is done, even if the source is blocked.
+func (p *pipeline) nextResult(ctx context.Context) (result, bool) {
+	for {
+		if r, ok := p.pending[p.next]; ok {
+			delete(p.pending, p.next)
+			p.next++
+			<-p.window
+			return r, true
+		}
+		select {
+		case r, ok := <-p.results:
+			if !ok {
+				return result{}, false
+			}
+			p.pending[r.seq] = r
+		case <-ctx.Done():
+			return result{}, false
+		}
+	}
+}
+
+// stop stops the goroutines of the pipeline and waits for the workers to
+// return. It does not wait for a read of the source in progress, which may be
+// blocked, e.g. on stdin, until the source is closed; the reader returns after
+// it without reading the source again.
+func (p *pipeline) stop() {
+	close(p.done)
+	p.wg.Wait()
+}
@@ -0,0 +1,244 @@
+package extract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeSource is a RecordSource of numbered records, which decode slowly and in
+// random order of completion. Records listed in undecodable are skipped, and
+// reading fails with err at record errAt, if set.
+type fakeSource struct {
+	n           int
+	undecodable map[int]bool
+	errAt       int
+	err         error
+	blockAt     int           // Reading blocks at this record until unblock is closed, if set
+	unblock     chan struct{} // Closed to let reading go on
+	read        atomic.Int64
+}
+
+func (s *fakeSource) NextRecord() ([]byte, error) {
+	i := int(s.read.Load())
+	if s.unblock != nil && i == s.blockAt {
+		<-s.unblock
+		return nil, io.ErrClosedPipe
+	}
+	if s.err != nil && i == s.errAt {
+		return nil, s.err
+	}
+	if i >= s.n {
+		return nil, io.EOF
+	}
+	s.read.Add(1)
+	return []byte(strconv.Itoa(i)), nil
+}
+
+func (s *fakeSource) Decode(record []byte) (Entry, bool) {
+	time.Sleep(time.Duration(rand.IntN(200)) * time.Microsecond)
+	i, _ := strconv.Atoi(string(record))
+	if s.undecodable[i] {
+		return Entry{}, false
+	}
+	return Entry{Word: string(record), Lang: "en"}, true
+}
+
+func (s *fakeSource) Next() (Entry, error) {
+	record, err := s.NextRecord()
+	if err != nil {
+		return Entry{}, err
+	}
+	e, _ := s.Decode(record)
+	return e, nil
+}
+
+func (s *fakeSource) Close() error { return nil }
+
+// collect returns every result of p, failing the test if they are not numbered
+// in order from 0.
+func collect(t *testing.T, p *pipeline) []result {
+	t.Helper()
+	results := []result{}
+	for {
+		r, ok := p.nextResult(context.Background())
+		if !ok {
+			return results
+		}
+		if r.seq != len(results) {
+			t.Fatalf("result %d has sequence number %d", len(results), r.seq)
+		}
+		results = append(results, r)
+	}
+}
+
+func TestPipelineOrder(t *testing.T) {
+	for _, workers := range []int{1, 2, 8} {
+		t.Run(strconv.Itoa(workers), func(t *testing.T) {
+			src := &fakeSource{n: 500, undecodable: map[int]bool{3: true, 250: true}}
+			p := startPipeline(src, nil, workers, 0)
+			defer p.stop()
+
+			results := collect(t, p)
+			if len(results) != src.n {
+				t.Fatalf("got %d results, want %d", len(results), src.n)
+			}
+			for i, r := range results {
+				if r.err != nil || r.skipped != src.undecodable[i] {
+					t.Errorf("result %d = %+v, want skipped %v", i, r, src.undecodable[i])
+				}
+				if !r.skipped && r.entry.Word != strconv.Itoa(i) {
+					t.Errorf("result %d has entry %q", i, r.entry.Word)
+				}
+			}
+		})
+	}
+}
+
+func TestPipelineSkip(t *testing.T) {
+	for _, skip := range []int64{0, 1, 40, 100} {
+		for _, workers := range []int{1, 8} {
+			t.Run(fmt.Sprintf("%d/%d", skip, workers), func(t *testing.T) {
+				src := &fakeSource{n: 100}
+				p := startPipeline(src, nil, workers, skip)
+				defer p.stop()
+
+				results := collect(t, p)
+				if len(results) != src.n-int(skip) {
+					t.Fatalf("got %d results, want %d", len(results), src.n-int(skip))
+				}
+				for i, r := range results {
+					if want := strconv.Itoa(i + int(skip)); r.err != nil || r.entry.Word != want {
+						t.Errorf("result %d = %+v, want entry %s", i, r, want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestPipelineErrors(t *testing.T) {
+	errRead := errors.New("read failed")
+	tests := []struct {
+		name    string
+		src     *fakeSource
+		skip    int64
+		wantN   int // Results before the error
+		wantErr string
+	}{
+		{"read error", &fakeSource{n: 100, errAt: 40, err: errRead}, 0, 40, errRead.Error()},
+		{"error while skipping", &fakeSource{n: 100, errAt: 10, err: errRead}, 20, 0, errRead.Error()},
+		{"source shorter than skip", &fakeSource{n: 10}, 20, 0, "source ended before the 20 records to skip"},
+	}
+	for _, tt := range tests {
+		for _, workers := range []int{1, 2, 8} {
+			t.Run(tt.name+"/"+strconv.Itoa(workers), func(t *testing.T) {
+				tt.src.read.Store(0)
+				p := startPipeline(tt.src, nil, workers, tt.skip)
+				defer p.stop()
+
+				results := collect(t, p)
+				if len(results) != tt.wantN+1 {
+					t.Fatalf("got %d results, want %d and the error", len(results), tt.wantN)
+				}
+				for _, r := range results[:tt.wantN] {
+					if r.err != nil {
+						t.Errorf("result %d error = %v, want none", r.seq, r.err)
+					}
+				}
+				if last := results[tt.wantN]; last.err == nil || last.err.Error() != tt.wantErr {
+					t.Errorf("last result error = %v, want %q", last.err, tt.wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestPipelineStop(t *testing.T) {
+	for _, workers := range []int{1, 2, 8} {
+		t.Run(strconv.Itoa(workers), func(t *testing.T) {
+			src := &fakeSource{n: 100000}
+			p := startPipeline(src, nil, workers, 0)
+			for range 5 {
+				p.nextResult(context.Background())
+			}
+
+			// Wait for reading to stall, with the workers blocked on results
+			// nobody receives
+			read := src.read.Load()
+			for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); {
+				time.Sleep(20 * time.Millisecond)
+				n := src.read.Load()
+				if n == read {
+					break
+				}
+				read = n
+			}
+			if read >= int64(src.n) {
+				t.Fatalf("read all %d records without receiving their results", read)
+			}
+
+			stopped := make(chan struct{})
+			go func() {
+				p.stop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-time.After(5 * time.Second):
+				t.Fatal("stop() did not return")
+			}
+			if n := src.read.Load(); n != read {
+				t.Errorf("read %d records after stop(), want %d", n, read)
+			}
+		})
+	}
+}
+
+func TestPipelineBlockedSource(t *testing.T) {
+	for _, workers := range []int{1, 8} {
+		t.Run(strconv.Itoa(workers), func(t *testing.T) {
+			src := &fakeSource{n: 100, blockAt: 10, unblock: make(chan struct{})}
+			p := startPipeline(src, nil, workers, 0)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			for i := range 10 {
+				if r, ok := p.nextResult(ctx); !ok || r.seq != i {
+					t.Fatalf("result %d = %+v, %v", i, r, ok)
+				}
+			}
+			time.AfterFunc(20*time.Millisecond, cancel)
+			if r, ok := p.nextResult(ctx); ok {
+				t.Fatalf("nextResult() = %+v after cancelling, want none", r)
+			}
+
+			stopped := make(chan struct{})
+			go func() {
+				p.stop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-time.After(5 * time.Second):
+				t.Fatal("stop() did not return while the source was blocked")
+			}
+
+			// Closing the source unblocks the reader, which then returns
+			close(src.unblock)
+			select {
+			case <-p.readDone:
+			case <-time.After(5 * time.Second):
+				t.Fatal("the reader did not return after the source was unblocked")
+			}
+			if n := src.read.Load(); n != 10 {
+				t.Errorf("read %d records, want 10", n)
+			}
+		})
+	}
+}
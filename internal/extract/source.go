@@ -0,0 +1,101 @@
+package extract
+
+// Entry is a dictionary entry normalized from a Source: one word in one part of
+// speech. Sources fill in whatever fields their format provides.
+type Entry struct {
+	Word            string
+	Lang            string // Language code, e.g. "en"
+	Pos             string // Part of speech, e.g. "noun", or "" if unknown
+	EtymologyNumber int    // Distinguishes entries of the same word and pos, 0 if unused
+	Senses          []Sense
+	Forms           []Form   // Inflections of the word, e.g. its plural
+	Sounds          []Sound  // Pronunciations of the word
+	Syllables       []string // Hyphenated parts of the word, empty if unknown
+}
+
+// Sense is one meaning of an Entry.
+type Sense struct {
+	Gloss  string
+	Tags   []string
+	Lemmas []string // Words the entry is a form or alternative spelling of in this sense
+
+	// Sources with synsets, such as WordNet, also identify the sense so that
+	// relations can refer to it
+	Key       string     // Identifies the sense in its source, "" if unused
+	Synset    *Synset    // Set of synonyms the sense belongs to, if Key is set
+	Relations []Relation // Relations to senses of other words, e.g. antonyms
+}
+
+// Synset is a set of senses of different words that share a meaning. Its gloss
+// is that of its senses.
+type Synset struct {
+	Key       string     // Identifies the synset in its source
+	Relations []Relation // Relations to other synsets, e.g. hypernyms
+}
+
+// Relation is a typed link to a sense or synset identified by Target.
+type Relation struct {
+	Type   string // e.g. "antonym" or "hypernym"
+	Target string
+}
+
+// Form is an inflected form of the word of an Entry.
+type Form struct {
+	Form string
+	Tags []string
+}
+
+// Sound is a pronunciation of the word of an Entry, usually holding only one of
+// an IPA transcription, a rhyme or an audio file name.
+type Sound struct {
+	IPA   string
+	Rhyme string
+	Audio string
+	Tags  []string
+}
+
+// Source yields the entries of a dictionary. Next returns the next entry, or
+// io.EOF once every entry has been read. Close releases the source's resources.
+type Source interface {
+	Next() (Entry, error)
+	Close() error
+}
+
+// RecordSource is a Source whose entries are read as raw records, such as JSON
+// lines, that are costly to decode. ToDB reads the records in order with
+// NextRecord, which returns io.EOF after the last one, and decodes them
+// concurrently with Decode, which must be safe for concurrent use and returns
+// false for records that cannot be decoded.
+type RecordSource interface {
+	Source
+	NextRecord() ([]byte, error)
+	Decode(record []byte) (Entry, bool)
+}
+
+// OptionalSensesSource is a Source whose entries need not have senses, such as
+// a word list, unlike a dictionary whose entries without senses are incomplete.
+// If SensesOptional returns true, its entries without senses are kept by filters
+// regardless of FilterConfig.RequireDefinition, which still applies to entries
+// with senses.
+type OptionalSensesSource interface {
+	Source
+	SensesOptional() bool
+}
+
+// LicensedSource is a Source whose raw file states the license of its content.
+// The license is recorded with the import run reading it unless one is given.
+type LicensedSource interface {
+	Source
+	License() string
+}
+
+// MultiFileSource is a Source read from several raw files, such as a Hunspell
+// dictionary and its affix file. Files returns their paths, all of which are
+// hashed to identify the import run reading them.
+type MultiFileSource interface {
+	Source
+	Files() []string
+}
+
+// HyphenationSeparator separates the syllables of a hyphenated word.
+const HyphenationSeparator = "‧"
@@ -0,0 +1,126 @@
+package gaddag
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/pbojar/dictextract/internal/dawg"
+)
+
+// Separator marks the end of the reversed prefix in a GADDAG path. It must not
+// appear in any word added to the GADDAG.
+const Separator = '>'
+
+// GADDAG is an immutable GADDAG: a minimized DAWG over every path
+// REV(prefix)+Separator+suffix of every word, where prefix is non-empty. When the
+// suffix is empty the separator is left out, so REV(word) is also a path.
+// Starting from any letter of a word, a GADDAG can spell the word outwards, first
+// to the left and then to the right, which is what move generation from an
+// anchor square needs.
+type GADDAG struct {
+	dawg *dawg.DAWG
+}
+
+// Build creates a GADDAG from words. The words need not be sorted and may
+// contain duplicates; their paths are built into a minimized DAWG.
+func Build(words []string) (*GADDAG, error) {
+	allPaths := []string{}
+	for _, w := range words {
+		for _, r := range w {
+			if r == Separator {
+				return nil, fmt.Errorf("word '%s' contains the separator '%c'", w, Separator)
+			}
+		}
+		allPaths = append(allPaths, paths(w)...)
+	}
+	d, err := dawg.Build(allPaths)
+	if err != nil {
+		return nil, err
+	}
+	return &GADDAG{dawg: d}, nil
+}
+
+// paths returns the GADDAG paths of word, one per split point.
+func paths(word string) []string {
+	runes := []rune(word)
+	out := make([]string, 0, len(runes))
+	for i := 1; i <= len(runes); i++ {
+		path := make([]rune, 0, len(runes)+1)
+		for j := i - 1; j >= 0; j-- {
+			path = append(path, runes[j])
+		}
+		if i < len(runes) {
+			path = append(path, Separator)
+			path = append(path, runes[i:]...)
+		}
+		out = append(out, string(path))
+	}
+	return out
+}
+
+// Root returns the root node of the GADDAG. Edges leaving the root are the
+// letters a word can be started from; Separator edges switch from extending left
+// to extending right.
+func (g *GADDAG) Root() *dawg.DAWGNode {
+	return g.dawg.Root()
+}
+
+// Contains checks if a word exists in the GADDAG.
+func (g *GADDAG) Contains(word string) bool {
+	return word != "" && g.dawg.Contains(reverse(word))
+}
+
+// WordsContaining returns every word in the GADDAG that contains infix, in
+// ascending order. The search starts at infix and extends outwards in both
+// directions, so no word without infix is ever visited.
+func (g *GADDAG) WordsContaining(infix string) []string {
+	if infix == "" {
+		return []string{}
+	}
+	node := g.dawg.Root()
+	for _, r := range reverse(infix) {
+		node = node.Child(r)
+		if node == nil {
+			return []string{}
+		}
+	}
+
+	words := []string{}
+	var left, right []rune
+	var search func(node *dawg.DAWGNode, extendingRight bool)
+	search = func(node *dawg.DAWGNode, extendingRight bool) {
+		if node.IsTerminal() {
+			words = append(words, reverse(string(left))+infix+string(right))
+		}
+		for _, r := range node.Edges() {
+			child := node.Child(r)
+			switch {
+			case r == Separator:
+				if !extendingRight {
+					search(child, true)
+				}
+			case extendingRight:
+				right = append(right, r)
+				search(child, true)
+				right = right[:len(right)-1]
+			default:
+				left = append(left, r)
+				search(child, false)
+				left = left[:len(left)-1]
+			}
+		}
+	}
+	search(node, false)
+
+	// A word containing infix more than once is reached once per occurrence
+	sort.Strings(words)
+	return slices.Compact(words)
+}
+
+// reverse returns s with its runes in reverse order.
+func reverse(s string) string {
+	runes := []rune(s)
+	slices.Reverse(runes)
+	return string(runes)
+}
@@ -0,0 +1,142 @@
+package gaddag
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestPaths(t *testing.T) {
+	expected := []string{"c>at", "ac>t", "tac"}
+	result := paths("cat")
+	if !slices.Equal(result, expected) {
+		t.Errorf("paths(cat) returned %v, expected %v", result, expected)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	_, err := Build([]string{"cat", "c>t"})
+	if err == nil {
+		t.Errorf("Build() accepted a word containing the separator")
+	}
+}
+
+func TestGADDAGContains(t *testing.T) {
+	g, err := Build([]string{"dogs", "cat", "care", "car", "dog"})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		word     string
+		expected bool
+	}{
+		{
+			name:     "GADDAG contains cat",
+			word:     "cat",
+			expected: true,
+		},
+		{
+			name:     "GADDAG contains care",
+			word:     "care",
+			expected: true,
+		},
+		{
+			name:     "GADDAG doesn't contain ca",
+			word:     "ca",
+			expected: false,
+		},
+		{
+			name:     "GADDAG doesn't contain tac",
+			word:     "tac",
+			expected: false,
+		},
+		{
+			name:     "GADDAG doesn't contain the empty word",
+			word:     "",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := g.Contains(tt.word)
+			if result != tt.expected {
+				t.Errorf("gaddag.Contains(%s) returned %t, expected %t", tt.word, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGADDAGWordsContaining(t *testing.T) {
+	g, err := Build([]string{"cat", "car", "care", "scar", "dog", "dogs", "tart", "art"})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		infix    string
+		expected []string
+	}{
+		{
+			name:     "Words containing ar",
+			infix:    "ar",
+			expected: []string{"art", "car", "care", "scar", "tart"},
+		},
+		{
+			name:     "Words containing t",
+			infix:    "t",
+			expected: []string{"art", "cat", "tart"},
+		},
+		{
+			name:     "Whole word",
+			infix:    "dogs",
+			expected: []string{"dogs"},
+		},
+		{
+			name:     "Missing infix",
+			infix:    "zz",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := g.WordsContaining(tt.infix)
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("gaddag.WordsContaining(%s) returned %v, expected %v", tt.infix, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSaveAsAndLoadFromGob(t *testing.T) {
+	words := []string{"cat", "car", "care", "dog"}
+	g, err := Build(words)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	tF, err := os.CreateTemp("", "testGaddag.gob")
+	if err != nil {
+		t.Fatalf("error creating temporary file: %v", err)
+	}
+	gaddagFilePath := tF.Name()
+	tF.Close()
+	defer os.Remove(gaddagFilePath)
+
+	if err := g.SaveAsGob(gaddagFilePath); err != nil {
+		t.Fatalf("error saving as gob: %v", err)
+	}
+	loaded, err := LoadGADDAGFromGob(gaddagFilePath)
+	if err != nil {
+		t.Fatalf("error loading GADDAG from gob: %v", err)
+	}
+	for _, word := range words {
+		if !loaded.Contains(word) {
+			t.Errorf("'%s' not found in loaded GADDAG", word)
+		}
+	}
+}
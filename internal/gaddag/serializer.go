@@ -0,0 +1,18 @@
+package gaddag
+
+import "github.com/pbojar/dictextract/internal/dawg"
+
+// SaveAsGob writes the GADDAG to a file at the given path using gob encoding.
+// The file has the same format as a DAWG saved with dawg.DAWG.SaveAsGob.
+func (g *GADDAG) SaveAsGob(path string) error {
+	return g.dawg.SaveAsGob(path)
+}
+
+// LoadGADDAGFromGob reads a gob-encoded GADDAG from a file and reconstructs it.
+func LoadGADDAGFromGob(path string) (*GADDAG, error) {
+	d, err := dawg.LoadDAWGFromGob(path)
+	if err != nil {
+		return nil, err
+	}
+	return &GADDAG{dawg: d}, nil
+}
@@ -0,0 +1,95 @@
+package grid
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pbojar/dictextract/internal/dawg"
+)
+
+// ParseGrid converts rows of a letter grid into cells. A row containing commas
+// is split on them, so multi-letter cells can be given as e.g. "qu,a,r,t";
+// otherwise every rune of the row is a cell. Cells are lowercased to match the
+// words stored in the DB.
+func ParseGrid(rows []string) [][]string {
+	grid := make([][]string, len(rows))
+	for i, row := range rows {
+		row = strings.ToLower(row)
+		if strings.Contains(row, ",") {
+			grid[i] = strings.Split(row, ",")
+			continue
+		}
+		for _, r := range row {
+			grid[i] = append(grid[i], string(r))
+		}
+	}
+	return grid
+}
+
+// Solve returns every word in dict with at least minLen runes that can be traced
+// through horizontally, vertically or diagonally adjacent cells of grid without
+// using a cell twice. A cell may hold several letters (e.g. "qu"), all of which
+// are added to the word. Paths are abandoned as soon as they stop being a prefix
+// of a word in dict. Words are returned in ascending order.
+func Solve(dict *dawg.DAWG, grid [][]string, minLen int) ([]string, error) {
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return nil, fmt.Errorf("grid must have at least one cell")
+	}
+	for i, row := range grid {
+		if len(row) != len(grid[0]) {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", i, len(row), len(grid[0]))
+		}
+		for j, cell := range row {
+			if cell == "" {
+				return nil, fmt.Errorf("cell (%d, %d) is empty", i, j)
+			}
+		}
+	}
+
+	found := []string{}
+	used := make([][]bool, len(grid))
+	for i := range used {
+		used[i] = make([]bool, len(grid[i]))
+	}
+	word := []rune{}
+	var search func(node *dawg.DAWGNode, row, col int)
+	search = func(node *dawg.DAWGNode, row, col int) {
+		// Follow every letter of the cell before moving on
+		wordLen := len(word)
+		for _, r := range grid[row][col] {
+			node = node.Child(r)
+			if node == nil {
+				word = word[:wordLen]
+				return
+			}
+			word = append(word, r)
+		}
+
+		if node.IsTerminal() && len(word) >= minLen {
+			found = append(found, string(word))
+		}
+		used[row][col] = true
+		for dr := -1; dr <= 1; dr++ {
+			for dc := -1; dc <= 1; dc++ {
+				r, c := row+dr, col+dc
+				if r < 0 || r >= len(grid) || c < 0 || c >= len(grid[r]) || used[r][c] {
+					continue
+				}
+				search(node, r, c)
+			}
+		}
+		used[row][col] = false
+		word = word[:wordLen]
+	}
+	for row := range grid {
+		for col := range grid[row] {
+			search(dict.Root(), row, col)
+		}
+	}
+
+	// The same word can often be traced along more than one path
+	sort.Strings(found)
+	return slices.Compact(found), nil
+}
@@ -0,0 +1,81 @@
+package grid
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pbojar/dictextract/internal/dawg/dawgtest"
+)
+
+func TestParseGrid(t *testing.T) {
+	grid := ParseGrid([]string{"CAT", "Qu,i,t"})
+	expected := [][]string{{"c", "a", "t"}, {"qu", "i", "t"}}
+	if len(grid) != len(expected) {
+		t.Fatalf("ParseGrid() returned %v, expected %v", grid, expected)
+	}
+	for i := range expected {
+		if !slices.Equal(grid[i], expected[i]) {
+			t.Errorf("row %d is %v, expected %v", i, grid[i], expected[i])
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+	dict := dawgtest.Build(t, []string{
+		"act",
+		"at",
+		"cat",
+		"quit",
+		"quite",
+		"tact",
+		"tat",
+		"taxi",
+	})
+	// c a t
+	// qu i t
+	// x e s
+	grid := ParseGrid([]string{"cat", "qu,i,t", "xes"})
+
+	tests := []struct {
+		name     string
+		minLen   int
+		expected []string
+	}{
+		{
+			name:     "All words",
+			minLen:   1,
+			expected: []string{"at", "cat", "quit", "quite", "tat"},
+		},
+		{
+			name:     "Minimum length counts the letters of multi-letter cells",
+			minLen:   4,
+			expected: []string{"quit", "quite"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Solve(dict, grid, tt.minLen)
+			if err != nil {
+				t.Fatalf("Solve() error = %v", err)
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("Solve() returned %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSolveInvalidGrid(t *testing.T) {
+	dict := dawgtest.Build(t, []string{"at"})
+	grids := [][][]string{
+		{},
+		{{"a", "t"}, {"a"}},
+		{{"a", ""}},
+	}
+	for _, g := range grids {
+		if _, err := Solve(dict, g, 1); err == nil {
+			t.Errorf("Solve() accepted invalid grid %v", g)
+		}
+	}
+}
@@ -0,0 +1,352 @@
+package hunspell
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// charSet matches one rune of an affix condition.
+type charSet struct {
+	any    bool   // Matches every rune, for '.'
+	negate bool   // Matches runes not in runes, for [^...]
+	runes  []rune // Runes matched, for a literal or [...]
+}
+
+func (c charSet) matches(r rune) bool {
+	if c.any {
+		return true
+	}
+	return slices.Contains(c.runes, r) != c.negate
+}
+
+// affix is a prefix or suffix rule of an affix file. The rule strips strip from
+// the start or end of a word matching condition and adds add in its place.
+type affix struct {
+	strip     string
+	add       string
+	flags     []string // Continuation flags of the affixes that may follow this one
+	condition []charSet
+}
+
+// applyPrefix returns word with the rule applied as a prefix, and whether the
+// rule applies to word.
+func (a affix) applyPrefix(word string) (string, bool) {
+	runes := []rune(word)
+	if len(runes) < len(a.condition) || !strings.HasPrefix(word, a.strip) || len(a.strip) >= len(word) {
+		return "", false
+	}
+	for i, c := range a.condition {
+		if !c.matches(runes[i]) {
+			return "", false
+		}
+	}
+	return a.add + word[len(a.strip):], true
+}
+
+// applySuffix returns word with the rule applied as a suffix, and whether the
+// rule applies to word.
+func (a affix) applySuffix(word string) (string, bool) {
+	runes := []rune(word)
+	if len(runes) < len(a.condition) || !strings.HasSuffix(word, a.strip) || len(a.strip) >= len(word) {
+		return "", false
+	}
+	offset := len(runes) - len(a.condition)
+	for i, c := range a.condition {
+		if !c.matches(runes[offset+i]) {
+			return "", false
+		}
+	}
+	return word[:len(word)-len(a.strip)] + a.add, true
+}
+
+// affixClass is the set of rules sharing a flag.
+type affixClass struct {
+	cross bool // Whether prefixes and suffixes of the class combine
+	rules []affix
+}
+
+// affixes holds the rules of an affix file that are needed to expand words.
+type affixes struct {
+	flagType  string     // "" for single rune flags, "long", "num" or "UTF-8"
+	aliases   [][]string // Flag sets referred to by number when AF is used
+	prefixes  map[string]*affixClass
+	suffixes  map[string]*affixClass
+	needAffix string // Flag of words and affixes that are not forms on their own
+	forbidden string // Flag of words that are not valid
+}
+
+// parseAffixes parses the text of an affix file. Options other than those used
+// to expand words, such as compounding and suggestion options, are ignored.
+func parseAffixes(text string) (*affixes, error) {
+	a := &affixes{
+		prefixes: make(map[string]*affixClass),
+		suffixes: make(map[string]*affixClass),
+	}
+	for i, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if err := a.parseLine(fields); err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+1, err)
+		}
+	}
+	return a, nil
+}
+
+func (a *affixes) parseLine(fields []string) error {
+	switch fields[0] {
+	case "FLAG":
+		if len(fields) > 1 {
+			a.flagType = fields[1]
+		}
+	case "AF":
+		if len(fields) < 2 {
+			return nil
+		}
+		// The first AF line gives the number of aliases
+		if a.aliases == nil {
+			a.aliases = [][]string{}
+			return nil
+		}
+		a.aliases = append(a.aliases, a.splitFlags(fields[1]))
+	case "NEEDAFFIX", "PSEUDOROOT":
+		if len(fields) > 1 {
+			a.needAffix = fields[1]
+		}
+	case "FORBIDDENWORD":
+		if len(fields) > 1 {
+			a.forbidden = fields[1]
+		}
+	case "PFX", "SFX":
+		classes := a.suffixes
+		if fields[0] == "PFX" {
+			classes = a.prefixes
+		}
+		if len(fields) < 4 {
+			return fmt.Errorf("expected at least 4 fields in %s rule", fields[0])
+		}
+		flag := fields[1]
+
+		// The first line of a class is its header: flag, cross product, count
+		class, ok := classes[flag]
+		if !ok {
+			classes[flag] = &affixClass{cross: fields[2] == "Y"}
+			return nil
+		}
+
+		rule := affix{}
+		if fields[2] != "0" {
+			rule.strip = fields[2]
+		}
+		add, flags, _ := strings.Cut(fields[3], "/")
+		if add != "0" {
+			rule.add = add
+		}
+		rule.flags = a.parseFlags(flags)
+		condition := "."
+		if len(fields) > 4 {
+			condition = fields[4]
+		}
+		var err error
+		rule.condition, err = parseCondition(condition)
+		if err != nil {
+			return err
+		}
+		class.rules = append(class.rules, rule)
+	}
+	return nil
+}
+
+// parseFlags splits a set of flags according to the flag type, resolving an
+// alias number if AF is used.
+func (a *affixes) parseFlags(s string) []string {
+	if a.aliases != nil {
+		if n, err := strconv.Atoi(s); err == nil && n >= 1 && n <= len(a.aliases) {
+			return a.aliases[n-1]
+		}
+	}
+	return a.splitFlags(s)
+}
+
+// splitFlags splits a set of flags according to the flag type.
+func (a *affixes) splitFlags(s string) []string {
+	flags := []string{}
+	if s == "" {
+		return flags
+	}
+	switch a.flagType {
+	case "long":
+		runes := []rune(s)
+		for i := 0; i+1 < len(runes); i += 2 {
+			flags = append(flags, string(runes[i:i+2]))
+		}
+	case "num":
+		flags = strings.Split(s, ",")
+	default:
+		for _, r := range s {
+			flags = append(flags, string(r))
+		}
+	}
+	return flags
+}
+
+// parseCondition parses an affix condition, a sequence of literal runes, '.'
+// for any rune and bracketed sets such as [aeiou] or [^aeiou].
+func parseCondition(s string) ([]charSet, error) {
+	condition := []charSet{}
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '.':
+			condition = append(condition, charSet{any: true})
+		case '[':
+			end := slices.Index(runes[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '[' in condition '%s'", s)
+			}
+			set := charSet{runes: runes[i+1 : i+end]}
+			if len(set.runes) > 0 && set.runes[0] == '^' {
+				set.negate = true
+				set.runes = set.runes[1:]
+			}
+			condition = append(condition, set)
+			i += end
+		default:
+			condition = append(condition, charSet{runes: []rune{runes[i]}})
+		}
+	}
+	return condition, nil
+}
+
+// expand returns the word of a dictionary line with the flags flags and every
+// form made from it by applying its affixes, without duplicates. Suffixes may
+// be followed by the suffixes of their continuation flags, and suffixes and
+// prefixes of classes allowing cross products combine.
+func (a *affixes) expand(word string, flags []string) []string {
+	if a.forbidden != "" && slices.Contains(flags, a.forbidden) {
+		return []string{}
+	}
+
+	forms := []string{}
+	add := func(form string, formFlags []string) {
+		if (a.needAffix == "" || !slices.Contains(formFlags, a.needAffix)) && !slices.Contains(forms, form) {
+			forms = append(forms, form)
+		}
+	}
+	add(word, flags)
+
+	for _, flag := range flags {
+		class, ok := a.suffixes[flag]
+		if !ok {
+			continue
+		}
+		for _, rule := range class.rules {
+			suffixed, ok := rule.applySuffix(word)
+			if !ok {
+				continue
+			}
+			add(suffixed, rule.flags)
+
+			// Twofold suffixes
+			for _, contFlag := range rule.flags {
+				if contClass, ok := a.suffixes[contFlag]; ok {
+					for _, contRule := range contClass.rules {
+						if form, ok := contRule.applySuffix(suffixed); ok {
+							add(form, contRule.flags)
+						}
+					}
+				}
+			}
+
+			// Prefixes combined with the suffix
+			if !class.cross {
+				continue
+			}
+			for _, prefixFlag := range slices.Concat(flags, rule.flags) {
+				prefixClass, ok := a.prefixes[prefixFlag]
+				if !ok || !prefixClass.cross {
+					continue
+				}
+				for _, prefixRule := range prefixClass.rules {
+					if form, ok := prefixRule.applyPrefix(suffixed); ok {
+						add(form, prefixRule.flags)
+					}
+				}
+			}
+		}
+	}
+
+	for _, flag := range flags {
+		class, ok := a.prefixes[flag]
+		if !ok {
+			continue
+		}
+		for _, rule := range class.rules {
+			if form, ok := rule.applyPrefix(word); ok {
+				add(form, rule.flags)
+			}
+		}
+	}
+	return forms
+}
+
+// encoding returns the encoding named by the SET option of the affix file raw,
+// which can be read before decoding since option names are ASCII.
+func encoding(raw []byte) string {
+	for _, line := range bytes.Split(raw, []byte("\n")) {
+		fields := bytes.Fields(line)
+		if len(fields) > 1 && string(fields[0]) == "SET" {
+			return string(fields[1])
+		}
+	}
+	return "ISO8859-1"
+}
+
+// charmaps maps the single byte encodings Hunspell names in SET options to
+// their character maps.
+var charmaps = map[string]*charmap.Charmap{
+	"ISO8859-1":        charmap.ISO8859_1,
+	"ISO8859-2":        charmap.ISO8859_2,
+	"ISO8859-3":        charmap.ISO8859_3,
+	"ISO8859-4":        charmap.ISO8859_4,
+	"ISO8859-5":        charmap.ISO8859_5,
+	"ISO8859-6":        charmap.ISO8859_6,
+	"ISO8859-7":        charmap.ISO8859_7,
+	"ISO8859-8":        charmap.ISO8859_8,
+	"ISO8859-9":        charmap.ISO8859_9,
+	"ISO8859-10":       charmap.ISO8859_10,
+	"ISO8859-13":       charmap.ISO8859_13,
+	"ISO8859-14":       charmap.ISO8859_14,
+	"ISO8859-15":       charmap.ISO8859_15,
+	"KOI8-R":           charmap.KOI8R,
+	"KOI8-U":           charmap.KOI8U,
+	"MICROSOFT-CP1251": charmap.Windows1251,
+	"TIS620-2533":      charmap.Windows874,
+}
+
+// decode converts text in the encoding enc to UTF-8.
+func decode(text []byte, enc string) (string, error) {
+	enc = strings.ToUpper(enc)
+	if enc == "UTF-8" {
+		if !utf8.Valid(text) {
+			return "", fmt.Errorf("invalid UTF-8")
+		}
+		return string(text), nil
+	}
+	cm, ok := charmaps[enc]
+	if !ok {
+		return "", fmt.Errorf("unsupported encoding '%s'", enc)
+	}
+	decoded, err := cm.NewDecoder().Bytes(text)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
@@ -0,0 +1,191 @@
+package hunspell
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+const testAff = `SET UTF-8
+
+# Plurals and past tenses
+SFX S Y 2
+SFX S   y     ies        [^aeiou]y
+SFX S   0     s          [^y]
+
+SFX D Y 3
+SFX D   0     ed         [^ey]
+SFX D   0     d          e
+SFX D   y     ied/S      [^aeiou]y
+
+PFX U Y 1
+PFX U   0     un         .
+
+PFX R N 1
+PFX R   0     re         .
+
+NEEDAFFIX X
+FORBIDDENWORD F
+`
+
+func TestExpand(t *testing.T) {
+	a, err := parseAffixes(testAff)
+	if err != nil {
+		t.Fatalf("parseAffixes failed: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		word  string
+		flags string
+		want  []string
+	}{
+		{"no flags", "cat", "", []string{"cat"}},
+		{"suffix", "cat", "S", []string{"cat", "cats"}},
+		{"suffix strips", "fly", "S", []string{"flies", "fly"}},
+		{"condition", "bake", "D", []string{"bake", "baked"}},
+		{"cross product", "tie", "DU", []string{"tie", "tied", "untie", "untied"}},
+		{"no cross product", "do", "RD", []string{"do", "doed", "redo"}},
+		{"twofold suffix", "spy", "D", []string{"spied", "spieds", "spy"}},
+		{"needs affix", "bak", "XS", []string{"baks"}},
+		{"forbidden", "cat", "FS", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := a.expand(tt.word, a.parseFlags(tt.flags))
+			sort.Strings(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expand(%q, %q) = %v, want %v", tt.word, tt.flags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlagTypes(t *testing.T) {
+	tests := []struct {
+		name  string
+		aff   string
+		flags string
+		want  []string
+	}{
+		{"short", "", "AB", []string{"A", "B"}},
+		{"long", "FLAG long", "AaBb", []string{"Aa", "Bb"}},
+		{"num", "FLAG num", "12,3", []string{"12", "3"}},
+		{"UTF-8", "FLAG UTF-8", "ÄÖ", []string{"Ä", "Ö"}},
+		{"alias", "AF 2\nAF AB\nAF C", "2", []string{"C"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := parseAffixes(tt.aff)
+			if err != nil {
+				t.Fatalf("parseAffixes failed: %v", err)
+			}
+			if got := a.parseFlags(tt.flags); !slices.Equal(got, tt.want) {
+				t.Errorf("parseFlags(%q) = %v, want %v", tt.flags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSource(t *testing.T) {
+	dir := t.TempDir()
+	dic := "4\ncat/S\nfly/S po:noun\nc\\/o\nParis/S\n"
+	if err := os.WriteFile(filepath.Join(dir, "en.aff"), []byte(testAff), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "en.dic"), []byte(dic), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := NewSource(filepath.Join(dir, "en.dic"), "en")
+	if err != nil {
+		t.Fatalf("NewSource failed: %v", err)
+	}
+	defer src.Close()
+
+	got := []string{}
+	for {
+		e, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next failed: %v", err)
+		}
+		if e.Lang != "en" {
+			t.Errorf("entry %q has lang %q, want en", e.Word, e.Lang)
+		}
+		got = append(got, e.Word+"/"+e.Pos)
+	}
+	want := []string{"cat/", "cats/", "fly/", "flies/", "c/o/", "Paris/name", "Pariss/name"}
+	if !slices.Equal(got, want) {
+		t.Errorf("words = %v, want %v", got, want)
+	}
+}
+
+func TestSourceEncodings(t *testing.T) {
+	tests := []struct {
+		name    string
+		enc     string
+		cm      *charmap.Charmap
+		aff     string
+		dic     string
+		want    []string
+		wantErr bool
+	}{
+		{"ISO8859-2", "ISO8859-2", charmap.ISO8859_2, "SFX A Y 1\nSFX A 0 ów .\n", "1\nżółw/A\n",
+			[]string{"żółw", "żółwów"}, false},
+		{"KOI8-R", "koi8-r", charmap.KOI8R, "SFX A Y 1\nSFX A 0 ы .\n", "1\nкот/A\n",
+			[]string{"кот", "коты"}, false},
+		{"default ISO8859-1", "", charmap.ISO8859_1, "SFX A Y 1\nSFX A 0 s .\n", "1\nété/A\n",
+			[]string{"été", "étés"}, false},
+		{"unsupported", "ISCII-DEVANAGARI", charmap.ISO8859_1, "", "1\ncat\n", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aff := tt.aff
+			if tt.enc != "" {
+				aff = "SET " + tt.enc + "\n" + aff
+			}
+			dir := t.TempDir()
+			for name, content := range map[string]string{"xx.aff": aff, "xx.dic": tt.dic} {
+				encoded, err := tt.cm.NewEncoder().String(content)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(encoded), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			src, err := NewSource(filepath.Join(dir, "xx.dic"), "xx")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer src.Close()
+			got := []string{}
+			for {
+				e, err := src.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next failed: %v", err)
+				}
+				got = append(got, e.Word)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("words = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
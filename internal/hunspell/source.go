@@ -0,0 +1,127 @@
+package hunspell
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pbojar/dictextract/internal/extract"
+)
+
+// Source is an extract.Source reading a Hunspell dictionary: a .dic file of
+// words with affix flags and the .aff file of the same name defining the affix
+// rules. Each word is expanded into its full forms, and each form becomes an
+// entry with no senses, which are optional to filters, and no part of speech,
+// except for forms of capitalized words, i.e. proper nouns, which get
+// Wiktionary's pos "name". Filter configs leave them out unless "allowed_pos"
+// has "name".
+type Source struct {
+	files   []string // Paths of the .dic and .aff files
+	lang    string
+	affixes *affixes
+	lines   []string // Lines of the .dic file left to read
+	pending []string // Forms of the last word read left to return
+	pos     string   // Of the last word read
+}
+
+// NewSource reads the dictionary at dicPath, whose words are in the language
+// with code lang, along with its affix file.
+func NewSource(dicPath, lang string) (*Source, error) {
+	affPath := strings.TrimSuffix(dicPath, ".dic") + ".aff"
+	rawAff, err := os.ReadFile(affPath)
+	if err != nil {
+		return nil, err
+	}
+	enc := encoding(rawAff)
+	affText, err := decode(rawAff, enc)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding '%s': %v", affPath, err)
+	}
+	a, err := parseAffixes(affText)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing '%s': %v", affPath, err)
+	}
+
+	rawDic, err := os.ReadFile(dicPath)
+	if err != nil {
+		return nil, err
+	}
+	dicText, err := decode(rawDic, enc)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding '%s': %v", dicPath, err)
+	}
+	lines := strings.Split(dicText, "\n")
+
+	// The first line gives the approximate number of words
+	if len(lines) > 0 {
+		if _, err := strconv.Atoi(strings.TrimSpace(lines[0])); err == nil {
+			lines = lines[1:]
+		}
+	}
+	return &Source{files: []string{dicPath, affPath}, lang: lang, affixes: a, lines: lines}, nil
+}
+
+// Next returns an entry for the next form in the dictionary, or io.EOF after the
+// last one.
+func (s *Source) Next() (extract.Entry, error) {
+	for len(s.pending) == 0 {
+		if len(s.lines) == 0 {
+			return extract.Entry{}, io.EOF
+		}
+		line := s.lines[0]
+		s.lines = s.lines[1:]
+
+		word, flags, ok := parseDicLine(line)
+		if !ok {
+			continue
+		}
+		s.pending = s.affixes.expand(word, s.affixes.parseFlags(flags))
+		s.pos = ""
+		if first, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(first) {
+			s.pos = "name"
+		}
+	}
+	form := s.pending[0]
+	s.pending = s.pending[1:]
+	return extract.Entry{Word: form, Lang: s.lang, Pos: s.pos}, nil
+}
+
+// Files returns the paths of the .dic and .aff files of the dictionary.
+func (s *Source) Files() []string {
+	return s.files
+}
+
+// SensesOptional returns true, since Hunspell dictionaries have no senses.
+func (s *Source) SensesOptional() bool {
+	return true
+}
+
+// Close releases the dictionary.
+func (s *Source) Close() error {
+	s.lines = nil
+	s.pending = nil
+	return nil
+}
+
+// parseDicLine splits a line of a .dic file into its word and flags, dropping
+// any morphological fields. Returns false for blank lines and comments.
+func parseDicLine(line string) (word, flags string, ok bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return "", "", false
+	}
+	entry := fields[0]
+
+	// A slash in a word is escaped with a backslash
+	for i := 0; i < len(entry); i++ {
+		if entry[i] == '/' && (i == 0 || entry[i-1] != '\\') {
+			word, flags = entry[:i], entry[i+1:]
+			return strings.ReplaceAll(word, `\/`, "/"), flags, word != ""
+		}
+	}
+	return strings.ReplaceAll(entry, `\/`, "/"), "", true
+}
@@ -0,0 +1,79 @@
+package movegen
+
+import "fmt"
+
+// Premium is the bonus printed on a board square. It only applies to the tile
+// placed on the square in the move that covers it.
+type Premium int
+
+const (
+	Plain Premium = iota
+	DoubleLetter
+	TripleLetter
+	DoubleWord
+	TripleWord
+)
+
+// multipliers returns the letter and word multipliers of a premium.
+func (p Premium) multipliers() (letterMult, wordMult int) {
+	switch p {
+	case DoubleLetter:
+		return 2, 1
+	case TripleLetter:
+		return 3, 1
+	case DoubleWord:
+		return 1, 2
+	case TripleWord:
+		return 1, 3
+	}
+	return 1, 1
+}
+
+// Square is one cell of a Board.
+type Square struct {
+	Letter  rune    // Letter of the tile on the square, or 0 if the square is empty
+	Blank   bool    // Whether the tile on the square is a blank, which scores 0
+	Premium Premium // Premium printed on the square
+}
+
+// Board is a grid of squares indexed as Board[row][col]. All rows must have the
+// same length.
+type Board [][]Square
+
+// validate checks that the board is non-empty and rectangular.
+func (b Board) validate() error {
+	if len(b) == 0 || len(b[0]) == 0 {
+		return fmt.Errorf("board must have at least one square")
+	}
+	for i, row := range b {
+		if len(row) != len(b[0]) {
+			return fmt.Errorf("row %d has %d squares, expected %d", i, len(row), len(b[0]))
+		}
+	}
+	return nil
+}
+
+// isEmpty reports whether no tiles have been placed on the board.
+func (b Board) isEmpty() bool {
+	for _, row := range b {
+		for _, sq := range row {
+			if sq.Letter != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// transpose returns a copy of the board with rows and columns swapped, so moves
+// down the original board can be generated as moves across the copy.
+func (b Board) transpose() Board {
+	t := make(Board, len(b[0]))
+	for c := range t {
+		t[c] = make([]Square, len(b))
+		for r := range b {
+			t[c][r] = b[r][c]
+		}
+	}
+	return t
+}
@@ -0,0 +1,387 @@
+package movegen
+
+import (
+	"sort"
+
+	"github.com/pbojar/dictextract/internal/dawg"
+)
+
+// Blank is the rune used for a blank tile in a rack.
+const Blank = '?'
+
+// Direction is the direction a move is played in.
+type Direction int
+
+const (
+	Across Direction = iota
+	Down
+)
+
+// Move is a legal placement of tiles from the rack.
+type Move struct {
+	Row, Col int       // Square of the first letter of Word
+	Dir      Direction // Direction Word is read in
+	Word     string    // Main word formed, including tiles already on the board
+	Placed   []int     // Positions in Word of the tiles placed by the move
+	Blanks   []int     // Positions in Word of the placed tiles that are blanks
+	Score    int
+}
+
+// Generator generates moves with the Appel–Jacobson algorithm: moves are built
+// outwards from anchor squares (empty squares next to a tile) by walking a DAWG,
+// with every placed letter checked against precomputed cross-checks so that any
+// perpendicular word formed is also valid.
+type Generator struct {
+	dict       *dawg.DAWG
+	scores     map[rune]int
+	RackSize   int // Number of tiles that must be placed to earn BingoBonus
+	BingoBonus int
+}
+
+// NewGenerator creates a Generator for the words in dict, scoring letters with
+// scores. Letters missing from scores are worth 0. The bingo bonus defaults to
+// 50 points for placing 7 tiles.
+func NewGenerator(dict *dawg.DAWG, scores map[rune]int) *Generator {
+	return &Generator{
+		dict:       dict,
+		scores:     scores,
+		RackSize:   7,
+		BingoBonus: 50,
+	}
+}
+
+// crossCheck holds what an empty square requires of a letter placed on it.
+type crossCheck struct {
+	hasWord bool          // Whether a tile placed here forms a perpendicular word
+	allowed map[rune]bool // Letters forming a valid perpendicular word, if hasWord
+	score   int           // Score of the tiles already in the perpendicular word
+}
+
+// allows reports whether r may be placed on the square.
+func (c crossCheck) allows(r rune) bool {
+	return !c.hasWord || c.allowed[r]
+}
+
+// Generate returns every legal move on board using the tiles in rack, where
+// Blank stands for a blank tile. On an empty board, moves must cover the center
+// square. Moves are sorted by descending score, then by position, direction and
+// word.
+func (g *Generator) Generate(board Board, rack string) ([]Move, error) {
+	if err := board.validate(); err != nil {
+		return nil, err
+	}
+
+	moves := []Move{}
+	for _, dir := range []Direction{Across, Down} {
+		b := board
+		if dir == Down {
+			b = board.transpose()
+		}
+		for _, m := range g.generateAcross(b, rack, board.isEmpty()) {
+			if dir == Down {
+				// A single tile forming words both ways was already found across
+				if len(m.Placed) == 1 && hasAcrossNeighbor(board, m.Col+m.Placed[0], m.Row) {
+					continue
+				}
+				m.Row, m.Col = m.Col, m.Row
+			}
+			m.Dir = dir
+			moves = append(moves, m)
+		}
+	}
+
+	sort.SliceStable(moves, func(i, j int) bool {
+		a, b := moves[i], moves[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Row != b.Row {
+			return a.Row < b.Row
+		}
+		if a.Col != b.Col {
+			return a.Col < b.Col
+		}
+		if a.Dir != b.Dir {
+			return a.Dir < b.Dir
+		}
+		return a.Word < b.Word
+	})
+	return moves, nil
+}
+
+// hasAcrossNeighbor reports whether the square at (row, col) has a tile to its
+// left or right.
+func hasAcrossNeighbor(board Board, row, col int) bool {
+	return (col > 0 && board[row][col-1].Letter != 0) ||
+		(col+1 < len(board[row]) && board[row][col+1].Letter != 0)
+}
+
+// generateAcross returns the moves across every row of board. Row and Col of
+// the returned moves index into board.
+func (g *Generator) generateAcross(board Board, rack string, firstMove bool) []Move {
+	crossChecks := g.crossChecks(board)
+	moves := []Move{}
+	for r, row := range board {
+		anchors := make([]bool, len(row))
+		for c := range row {
+			if firstMove {
+				anchors[c] = r == len(board)/2 && c == len(row)/2
+			} else {
+				anchors[c] = row[c].Letter == 0 && hasNeighbor(board, r, c)
+			}
+		}
+		rg := &rowGen{
+			g:      g,
+			row:    row,
+			rowIdx: r,
+			cross:  crossChecks[r],
+			counts: make(map[rune]int),
+		}
+		for _, l := range rack {
+			if l == Blank {
+				rg.blanks++
+			} else {
+				rg.counts[l]++
+			}
+		}
+		for c := range row {
+			if anchors[c] {
+				rg.fromAnchor(c, anchors)
+			}
+		}
+		moves = append(moves, rg.moves...)
+	}
+	return moves
+}
+
+// hasNeighbor reports whether any square orthogonally adjacent to (row, col)
+// has a tile.
+func hasNeighbor(board Board, row, col int) bool {
+	return (row > 0 && board[row-1][col].Letter != 0) ||
+		(row+1 < len(board) && board[row+1][col].Letter != 0) ||
+		hasAcrossNeighbor(board, row, col)
+}
+
+// crossChecks computes the cross-check of every empty square from the tiles
+// above and below it.
+func (g *Generator) crossChecks(board Board) [][]crossCheck {
+	checks := make([][]crossCheck, len(board))
+	for r, row := range board {
+		checks[r] = make([]crossCheck, len(row))
+		for c := range row {
+			if row[c].Letter != 0 {
+				continue
+			}
+			above := []rune{}
+			for i := r - 1; i >= 0 && board[i][c].Letter != 0; i-- {
+				above = append([]rune{board[i][c].Letter}, above...)
+			}
+			below := []rune{}
+			for i := r + 1; i < len(board) && board[i][c].Letter != 0; i++ {
+				below = append(below, board[i][c].Letter)
+			}
+			if len(above) == 0 && len(below) == 0 {
+				continue
+			}
+
+			check := crossCheck{hasWord: true, allowed: make(map[rune]bool)}
+			for i := r - 1; i >= 0 && board[i][c].Letter != 0; i-- {
+				check.score += g.tileScore(board[i][c].Letter, board[i][c].Blank)
+			}
+			for i := r + 1; i < len(board) && board[i][c].Letter != 0; i++ {
+				check.score += g.tileScore(board[i][c].Letter, board[i][c].Blank)
+			}
+
+			// Only letters following the tiles above in the DAWG can be valid
+			node := follow(g.dict.Root(), above)
+			if node != nil {
+				for _, l := range node.Edges() {
+					end := follow(node.Child(l), below)
+					if end != nil && end.IsTerminal() {
+						check.allowed[l] = true
+					}
+				}
+			}
+			checks[r][c] = check
+		}
+	}
+	return checks
+}
+
+// follow walks from node along runes, returning nil if an edge is missing.
+func follow(node *dawg.DAWGNode, runes []rune) *dawg.DAWGNode {
+	for _, r := range runes {
+		node = node.Child(r)
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// tileScore returns the face value of a tile.
+func (g *Generator) tileScore(letter rune, blank bool) int {
+	if blank {
+		return 0
+	}
+	return g.scores[letter]
+}
+
+// rowGen holds the state of move generation along a single row.
+type rowGen struct {
+	g      *Generator
+	row    []Square
+	rowIdx int
+	cross  []crossCheck
+	counts map[rune]int // Letters left in the rack
+	blanks int          // Blanks left in the rack
+
+	// Word being built, and for each of its letters whether it was placed from
+	// the rack and whether it is a blank
+	word   []rune
+	placed []bool
+	blank  []bool
+
+	moves []Move
+}
+
+// fromAnchor generates the moves whose leftmost anchor is the square at col.
+func (rg *rowGen) fromAnchor(col int, anchors []bool) {
+	// Tiles directly left of the anchor form a fixed left part
+	if col > 0 && rg.row[col-1].Letter != 0 {
+		start := col - 1
+		for start > 0 && rg.row[start-1].Letter != 0 {
+			start--
+		}
+		node := rg.g.dict.Root()
+		for c := start; c < col && node != nil; c++ {
+			node = node.Child(rg.row[c].Letter)
+			rg.push(rg.row[c].Letter, false, rg.row[c].Blank)
+		}
+		if node != nil {
+			rg.extendRight(node, col, col)
+		}
+		rg.word, rg.placed, rg.blank = rg.word[:0], rg.placed[:0], rg.blank[:0]
+		return
+	}
+
+	// Otherwise the left part is built from the rack on the empty, non-anchor
+	// squares to the left, which have no cross-checks
+	limit := 0
+	for c := col - 1; c >= 0 && !anchors[c] && rg.row[c].Letter == 0; c-- {
+		limit++
+	}
+	rg.leftPart(rg.g.dict.Root(), limit, col)
+}
+
+// leftPart extends the left part of a move by up to limit tiles, extending right
+// from the anchor after each one.
+func (rg *rowGen) leftPart(node *dawg.DAWGNode, limit, anchor int) {
+	rg.extendRight(node, anchor, anchor)
+	if limit == 0 {
+		return
+	}
+	for _, l := range node.Edges() {
+		rg.tryTile(l, node.Child(l), func(child *dawg.DAWGNode) {
+			rg.leftPart(child, limit-1, anchor)
+		})
+	}
+}
+
+// extendRight extends the word at col using the rack or the tile already on the
+// square, recording a move whenever a word ends past the anchor.
+func (rg *rowGen) extendRight(node *dawg.DAWGNode, col, anchor int) {
+	if col < len(rg.row) && rg.row[col].Letter != 0 {
+		l := rg.row[col].Letter
+		if child := node.Child(l); child != nil {
+			rg.push(l, false, rg.row[col].Blank)
+			rg.extendRight(child, col+1, anchor)
+			rg.pop()
+		}
+		return
+	}
+
+	if col > anchor && node.IsTerminal() && len(rg.word) >= 2 {
+		rg.record(col - len(rg.word))
+	}
+	if col >= len(rg.row) {
+		return
+	}
+	for _, l := range node.Edges() {
+		if !rg.cross[col].allows(l) {
+			continue
+		}
+		rg.tryTile(l, node.Child(l), func(child *dawg.DAWGNode) {
+			rg.extendRight(child, col+1, anchor)
+		})
+	}
+}
+
+// tryTile places l from the rack, first as a real tile and then as a blank, and
+// calls next with child for each placement that is possible.
+func (rg *rowGen) tryTile(l rune, child *dawg.DAWGNode, next func(child *dawg.DAWGNode)) {
+	if rg.counts[l] > 0 {
+		rg.counts[l]--
+		rg.push(l, true, false)
+		next(child)
+		rg.pop()
+		rg.counts[l]++
+	}
+	if rg.blanks > 0 {
+		rg.blanks--
+		rg.push(l, true, true)
+		next(child)
+		rg.pop()
+		rg.blanks++
+	}
+}
+
+func (rg *rowGen) push(l rune, placed, blank bool) {
+	rg.word = append(rg.word, l)
+	rg.placed = append(rg.placed, placed)
+	rg.blank = append(rg.blank, blank)
+}
+
+func (rg *rowGen) pop() {
+	rg.word = rg.word[:len(rg.word)-1]
+	rg.placed = rg.placed[:len(rg.placed)-1]
+	rg.blank = rg.blank[:len(rg.blank)-1]
+}
+
+// record scores the current word starting at start and adds it to the moves.
+func (rg *rowGen) record(start int) {
+	m := Move{
+		Row:    rg.rowIdx,
+		Col:    start,
+		Word:   string(rg.word),
+		Placed: []int{},
+		Blanks: []int{},
+	}
+	mainSum, wordMult, crossTotal := 0, 1, 0
+	for i, l := range rg.word {
+		col := start + i
+		if !rg.placed[i] {
+			mainSum += rg.g.tileScore(l, rg.blank[i])
+			continue
+		}
+		m.Placed = append(m.Placed, i)
+		if rg.blank[i] {
+			m.Blanks = append(m.Blanks, i)
+		}
+		letterMult, squareWordMult := rg.row[col].Premium.multipliers()
+		letterScore := rg.g.tileScore(l, rg.blank[i]) * letterMult
+		mainSum += letterScore
+		wordMult *= squareWordMult
+		if rg.cross[col].hasWord {
+			crossTotal += (rg.cross[col].score + letterScore) * squareWordMult
+		}
+	}
+	if len(m.Placed) == 0 {
+		return
+	}
+	m.Score = mainSum*wordMult + crossTotal
+	if len(m.Placed) == rg.g.RackSize {
+		m.Score += rg.g.BingoBonus
+	}
+	rg.moves = append(rg.moves, m)
+}
@@ -0,0 +1,196 @@
+package movegen
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pbojar/dictextract/internal/dawg/dawgtest"
+)
+
+var testScores = map[rune]int{'a': 1, 'b': 3, 'c': 3, 's': 1, 't': 1}
+
+// newBoard returns an empty size x size board with the given words placed
+// across, keyed by their starting square.
+func newBoard(size int, across map[[2]int]string) Board {
+	board := make(Board, size)
+	for r := range board {
+		board[r] = make([]Square, size)
+	}
+	for pos, word := range across {
+		for i, l := range []rune(word) {
+			board[pos[0]][pos[1]+i].Letter = l
+		}
+	}
+	return board
+}
+
+// applyMove returns a copy of board with the tiles of m placed on it.
+func applyMove(board Board, m Move) Board {
+	out := make(Board, len(board))
+	for r := range board {
+		out[r] = slices.Clone(board[r])
+	}
+	for i, l := range []rune(m.Word) {
+		r, c := m.Row, m.Col+i
+		if m.Dir == Down {
+			r, c = m.Row+i, m.Col
+		}
+		out[r][c].Letter = l
+	}
+	return out
+}
+
+// boardWords returns every run of two or more tiles on board, across and down.
+func boardWords(board Board) []string {
+	words := []string{}
+	for _, b := range []Board{board, board.transpose()} {
+		for _, row := range b {
+			run := []rune{}
+			for _, sq := range append(row, Square{}) {
+				if sq.Letter != 0 {
+					run = append(run, sq.Letter)
+					continue
+				}
+				if len(run) >= 2 {
+					words = append(words, string(run))
+				}
+				run = run[:0]
+			}
+		}
+	}
+	return words
+}
+
+func findMove(moves []Move, row, col int, dir Direction, word string) (Move, bool) {
+	for _, m := range moves {
+		if m.Row == row && m.Col == col && m.Dir == dir && m.Word == word {
+			return m, true
+		}
+	}
+	return Move{}, false
+}
+
+func TestGenerateFirstMove(t *testing.T) {
+	dict := dawgtest.Build(t, []string{"a", "act", "at", "cat", "ta"})
+	g := NewGenerator(dict, testScores)
+	board := newBoard(5, nil)
+	board[2][2].Premium = DoubleWord
+
+	moves, err := g.Generate(board, "tac")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// at and ta fit 2 ways and act and cat 3 ways over the center, in both directions
+	if len(moves) != 20 {
+		t.Errorf("Generate() returned %d moves, expected 20", len(moves))
+	}
+	for _, m := range moves {
+		if m.Row > 2 || m.Col > 2 || (m.Dir == Across && m.Row != 2) || (m.Dir == Down && m.Col != 2) {
+			t.Errorf("move %v does not cover the center square", m)
+		}
+	}
+	if moves[0].Score != 10 {
+		t.Errorf("best move %v scored %d, expected 10", moves[0], moves[0].Score)
+	}
+}
+
+func TestGenerateBlanksAndBingo(t *testing.T) {
+	dict := dawgtest.Build(t, []string{"act", "at", "cat", "ta"})
+	g := NewGenerator(dict, testScores)
+	g.RackSize = 3
+	board := newBoard(5, nil)
+
+	moves, err := g.Generate(board, "c?t")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	m, ok := findMove(moves, 2, 0, Across, "cat")
+	if !ok {
+		t.Fatalf("cat across from (2, 0) not generated")
+	}
+	if !slices.Equal(m.Blanks, []int{1}) || !slices.Equal(m.Placed, []int{0, 1, 2}) {
+		t.Errorf("cat placed %v with blanks %v, expected [0 1 2] with blanks [1]", m.Placed, m.Blanks)
+	}
+	if m.Score != 54 {
+		t.Errorf("cat scored %d, expected 54 (4 plus the bingo bonus)", m.Score)
+	}
+}
+
+func TestGenerateHooks(t *testing.T) {
+	dict := dawgtest.Build(t, []string{"as", "at", "cat", "cats", "scat", "ta"})
+	g := NewGenerator(dict, testScores)
+	board := newBoard(5, map[[2]int]string{{2, 1}: "cat"})
+
+	moves, err := g.Generate(board, "s")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	expected := []struct {
+		row, col int
+		dir      Direction
+		word     string
+		score    int
+	}{
+		{2, 0, Across, "scat", 6},
+		{2, 1, Across, "cats", 6},
+		{2, 2, Down, "as", 2},
+	}
+	if len(moves) != len(expected) {
+		t.Fatalf("Generate() returned %v, expected %d moves", moves, len(expected))
+	}
+	for i, e := range expected {
+		m := moves[i]
+		if m.Row != e.row || m.Col != e.col || m.Dir != e.dir || m.Word != e.word || m.Score != e.score {
+			t.Errorf("move %d is %v, expected %v", i, m, e)
+		}
+	}
+}
+
+func TestGenerateCrossChecks(t *testing.T) {
+	words := []string{"a", "ab", "as", "at", "bat", "bats", "cab", "cat", "cats", "sat", "scat", "tab", "tabs", "ta"}
+	dict := dawgtest.Build(t, words)
+	g := NewGenerator(dict, testScores)
+	board := newBoard(7, map[[2]int]string{{3, 1}: "cat", {4, 3}: "a"})
+
+	moves, err := g.Generate(board, "bsta")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(moves) == 0 {
+		t.Fatalf("Generate() returned no moves")
+	}
+
+	// Every word formed by every move must be in the dictionary, and a single
+	// tile forming words both ways must only be reported once
+	seen := make(map[string]bool)
+	for _, m := range moves {
+		for _, w := range boardWords(applyMove(board, m)) {
+			if !dict.Contains(w) {
+				t.Errorf("move %v forms invalid word '%s'", m, w)
+			}
+		}
+		if len(m.Placed) == 1 {
+			r, c := m.Row, m.Col+m.Placed[0]
+			if m.Dir == Down {
+				r, c = m.Row+m.Placed[0], m.Col
+			}
+			key := string(m.Word[m.Placed[0]]) + string(rune('0'+r)) + string(rune('0'+c))
+			if seen[key] {
+				t.Errorf("single tile move %v reported twice", m)
+			}
+			seen[key] = true
+		}
+	}
+}
+
+func TestGenerateInvalidBoard(t *testing.T) {
+	dict := dawgtest.Build(t, []string{"at"})
+	g := NewGenerator(dict, testScores)
+	if _, err := g.Generate(Board{}, "at"); err == nil {
+		t.Errorf("Generate() accepted an empty board")
+	}
+	if _, err := g.Generate(Board{make([]Square, 3), make([]Square, 2)}, "at"); err == nil {
+		t.Errorf("Generate() accepted a ragged board")
+	}
+}
@@ -0,0 +1,72 @@
+package wiktionary
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pbojar/dictextract/internal/extract"
+)
+
+// Form tags marking entries of "forms" that are not inflections, such as
+// inflection table headers.
+var ignoredFormTags = []string{"table-tags", "inflection-template", "class", "canonical", "romanization"}
+
+// entry normalizes w into an extract.Entry.
+func (w *wiktionLite) entry() extract.Entry {
+	e := extract.Entry{
+		Word:            w.Word,
+		Lang:            w.LangCode,
+		Pos:             w.Pos,
+		EtymologyNumber: w.EtymologyNumber,
+		Syllables:       w.syllables(),
+	}
+
+	for _, sense := range w.Senses {
+		// Glosses of subsenses start with those of their parents, so the last
+		// gloss is the most specific
+		s := extract.Sense{Tags: sense.Tags}
+		if len(sense.Glosses) > 0 {
+			s.Gloss = sense.Glosses[len(sense.Glosses)-1]
+		}
+		for _, link := range slices.Concat(sense.FormOf, sense.AltOf) {
+			s.Lemmas = append(s.Lemmas, link.Word)
+		}
+		e.Senses = append(e.Senses, s)
+	}
+
+	for _, form := range w.Forms {
+		if slices.ContainsFunc(form.Tags, func(tag string) bool { return slices.Contains(ignoredFormTags, tag) }) {
+			continue
+		}
+		e.Forms = append(e.Forms, extract.Form{Form: form.Form, Tags: form.Tags})
+	}
+
+	for _, sound := range w.Sounds {
+		e.Sounds = append(e.Sounds, extract.Sound{
+			IPA:   sound.IPA,
+			Rhyme: sound.Rhymes,
+			Audio: sound.Audio,
+			Tags:  sound.Tags,
+		})
+	}
+	return e
+}
+
+// syllables returns the parts of the first hyphenation of w that spells out its
+// word, or nil if there is none.
+func (w *wiktionLite) syllables() []string {
+	candidates := [][]string{}
+	for _, h := range w.Hyphenations {
+		candidates = append(candidates, h.Parts)
+	}
+	for _, h := range w.Hyphenation {
+		candidates = append(candidates, strings.Split(h, extract.HyphenationSeparator))
+	}
+	for _, parts := range candidates {
+		// Hyphenations of other spellings of the word are sometimes listed
+		if len(parts) > 0 && strings.EqualFold(strings.Join(parts, ""), w.Word) {
+			return parts
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package wiktionary
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+
+	"github.com/pbojar/dictextract/internal/extract"
+)
+
+// Source is an extract.RecordSource reading a gzipped Wiktextract JSONL dump, where
+// each line is a json following the wiktionLite structure. Lines that cannot be
+// parsed are logged and skipped.
+type Source struct {
+	file     *os.File
+	gzReader *gzip.Reader
+	scanner  *bufio.Scanner
+}
+
+// NewSource opens the gzipped dump at gzFilepath for reading.
+func NewSource(gzFilepath string) (*Source, error) {
+
+	// Open compressed file for reading
+	file, err := os.Open(gzFilepath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create GZIP reader
+	gzReader, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	// Use buffered scanner to read line by line
+	scanner := bufio.NewScanner(gzReader)
+	const maxCapacity int = 1 << 24 // Lines in the EN wiktionary are very long
+	buf := make([]byte, maxCapacity)
+	scanner.Buffer(buf, maxCapacity)
+
+	return &Source{
+		file:     file,
+		gzReader: gzReader,
+		scanner:  scanner,
+	}, nil
+}
+
+// Next returns the next entry of the dump, or io.EOF at its end.
+func (s *Source) Next() (extract.Entry, error) {
+	for {
+		record, err := s.NextRecord()
+		if err != nil {
+			return extract.Entry{}, err
+		}
+		if entry, ok := s.Decode(record); ok {
+			return entry, nil
+		}
+	}
+}
+
+// NextRecord returns the next line of the dump, or io.EOF at its end.
+func (s *Source) NextRecord() ([]byte, error) {
+	if s.scanner.Scan() {
+		// The scanner reuses its buffer, so the line is copied
+		return bytes.Clone(s.scanner.Bytes()), nil
+	}
+	if err := s.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+// Decode parses a line of the dump, logging lines that cannot be parsed.
+func (s *Source) Decode(record []byte) (extract.Entry, bool) {
+
+	// Unmarshal json
+	var w wiktionLite
+	if err := json.Unmarshal(record, &w); err != nil {
+		log.Printf("Error parsing JSON: %s", err)
+		return extract.Entry{}, false
+	}
+	return w.entry(), true
+}
+
+// Close closes the dump.
+func (s *Source) Close() error {
+	s.gzReader.Close()
+	return s.file.Close()
+}
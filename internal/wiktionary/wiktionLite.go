@@ -1,10 +1,49 @@
 package wiktionary
 
 type wiktionLite struct {
-	Senses []struct {
-		Glosses []string `json:"glosses"`
-	} `json:"senses"`
-	Pos      string `json:"pos"`
-	Word     string `json:"word"`
-	LangCode string `json:"lang_code"`
+	Senses          []wiktionSense `json:"senses"`
+	Forms           []wiktionForm  `json:"forms"`
+	Sounds          []wiktionSound `json:"sounds"`
+	Pos             string         `json:"pos"`
+	Word            string         `json:"word"`
+	LangCode        string         `json:"lang_code"`
+	EtymologyNumber int            `json:"etymology_number"`
+
+	// Older dumps give hyphenations as strings with parts separated by '‧'
+	Hyphenation  []string             `json:"hyphenation"`
+	Hyphenations []wiktionHyphenation `json:"hyphenations"`
+}
+
+// wiktionSense is one sense of a wiktionLite entry. Glosses of subsenses start
+// with the glosses of their parent senses, so the last gloss is the most specific.
+// FormOf and AltOf link inflections and alternative spellings to their lemmas.
+type wiktionSense struct {
+	Glosses []string      `json:"glosses"`
+	Tags    []string      `json:"tags"`
+	FormOf  []wiktionLink `json:"form_of"`
+	AltOf   []wiktionLink `json:"alt_of"`
+}
+
+// wiktionForm is an inflected form of a wiktionLite entry, e.g. a plural.
+type wiktionForm struct {
+	Form string   `json:"form"`
+	Tags []string `json:"tags"`
+}
+
+// wiktionSound is one item of the pronunciation section of a wiktionLite entry,
+// which usually holds only one of an IPA transcription, a rhyme or an audio file.
+type wiktionSound struct {
+	IPA    string   `json:"ipa"`
+	Rhymes string   `json:"rhymes"`
+	Audio  string   `json:"audio"`
+	Tags   []string `json:"tags"`
+}
+
+// wiktionHyphenation splits a wiktionLite entry's word into syllables.
+type wiktionHyphenation struct {
+	Parts []string `json:"parts"`
+}
+
+type wiktionLink struct {
+	Word string `json:"word"`
 }
@@ -0,0 +1,210 @@
+package wiktionary
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pbojar/dictextract/internal/extract"
+)
+
+// decode decodes the JSONL record line, failing the test if it cannot be.
+func decode(t *testing.T, line string) extract.Entry {
+	t.Helper()
+	e, ok := (&Source{}).Decode([]byte(line))
+	if !ok {
+		t.Fatalf("Decode(%s) failed", line)
+	}
+	return e
+}
+
+func TestDecodeEntry(t *testing.T) {
+	type sense struct {
+		gloss string
+		tags  []string
+	}
+	tests := []struct {
+		name   string
+		line   string
+		word   string
+		pos    string
+		etym   int
+		senses []sense
+	}{
+		{
+			"plain",
+			`{"word": "cat", "lang_code": "en", "pos": "noun", "senses": [{"glosses": ["A small feline."], "tags": ["common"]}]}`,
+			"cat", "noun", 0, []sense{{"A small feline.", []string{"common"}}},
+		},
+		{
+			"subsenses keep their last gloss",
+			`{"word": "cat", "lang_code": "en", "pos": "noun", "senses": [` +
+				`{"glosses": ["A feline."]}, ` +
+				`{"glosses": ["A feline.", "A domestic cat."], "tags": ["specifically"]}]}`,
+			"cat", "noun", 0, []sense{{"A feline.", nil}, {"A domestic cat.", []string{"specifically"}}},
+		},
+		{
+			"senses without glosses keep their index",
+			`{"word": "cat", "lang_code": "en", "pos": "verb", "etymology_number": 2, "senses": [` +
+				`{"tags": ["no-gloss"]}, {"glosses": ["To vomit."]}]}`,
+			"cat", "verb", 2, []sense{{"", []string{"no-gloss"}}, {"To vomit.", nil}},
+		},
+		{
+			"no senses",
+			`{"word": "été", "lang_code": "fr", "pos": "noun", "etymology_number": 1}`,
+			"été", "noun", 1, nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := decode(t, tt.line)
+			if e.Word != tt.word || e.Pos != tt.pos || e.EtymologyNumber != tt.etym {
+				t.Errorf("entry = %s (%s, etymology %d), want %s (%s, etymology %d)",
+					e.Word, e.Pos, e.EtymologyNumber, tt.word, tt.pos, tt.etym)
+			}
+			if len(e.Senses) != len(tt.senses) {
+				t.Fatalf("senses = %+v, want %d", e.Senses, len(tt.senses))
+			}
+			for i, s := range tt.senses {
+				if e.Senses[i].Gloss != s.gloss || !slices.Equal(e.Senses[i].Tags, s.tags) {
+					t.Errorf("sense %d = %+v, want %+v", i, e.Senses[i], s)
+				}
+			}
+		})
+	}
+}
+
+func TestSource(t *testing.T) {
+	lines := []string{
+		`{"word": "cat", "lang_code": "en", "pos": "noun"}`,
+		`{"word": "broken"`,
+		`{"word": "chat", "lang_code": "fr", "pos": "noun"}`,
+	}
+	path := filepath.Join(t.TempDir(), "dump.jsonl.gz")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := gzip.NewWriter(file)
+	if _, err := io.WriteString(zw, strings.Join(lines, "\n")+"\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Lines that cannot be parsed are logged
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	src, err := NewSource(path)
+	if err != nil {
+		t.Fatalf("NewSource() error = %v", err)
+	}
+	defer src.Close()
+	got := []string{}
+	for {
+		e, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		got = append(got, e.Word+"/"+e.Lang)
+	}
+	if want := []string{"cat/en", "chat/fr"}; !slices.Equal(got, want) {
+		t.Errorf("entries = %v, want %v", got, want)
+	}
+}
+
+func TestDecodeForms(t *testing.T) {
+	e := decode(t, `{"word": "cat", "lang_code": "en", "pos": "noun", "forms": [`+
+		`{"form": "no-table-tags", "tags": ["table-tags"]}, `+
+		`{"form": "en-noun", "tags": ["inflection-template"]}, `+
+		`{"form": "cats", "tags": ["plural"]}, `+
+		`{"form": "kat", "tags": ["romanization"]}, `+
+		`{"form": "cat's"}]}`)
+	want := []extract.Form{{Form: "cats", Tags: []string{"plural"}}, {Form: "cat's"}}
+	if len(e.Forms) != len(want) {
+		t.Fatalf("forms = %+v, want %+v", e.Forms, want)
+	}
+	for i := range want {
+		if e.Forms[i].Form != want[i].Form || !slices.Equal(e.Forms[i].Tags, want[i].Tags) {
+			t.Errorf("form %d = %+v, want %+v", i, e.Forms[i], want[i])
+		}
+	}
+}
+
+func TestDecodeLemmas(t *testing.T) {
+	e := decode(t, `{"word": "cats", "lang_code": "en", "pos": "noun", "senses": [`+
+		`{"glosses": ["plural of cat"], "tags": ["form-of", "plural"], "form_of": [{"word": "cat"}]}, `+
+		`{"glosses": ["Alternative form of katz"], "alt_of": [{"word": "katz"}, {"word": "kats"}]}, `+
+		`{"glosses": ["A jazz fan."]}]}`)
+	want := [][]string{{"cat"}, {"katz", "kats"}, nil}
+	if len(e.Senses) != len(want) {
+		t.Fatalf("senses = %+v, want %d", e.Senses, len(want))
+	}
+	for i := range want {
+		if !slices.Equal(e.Senses[i].Lemmas, want[i]) {
+			t.Errorf("lemmas of sense %d = %v, want %v", i, e.Senses[i].Lemmas, want[i])
+		}
+	}
+}
+
+func TestSyllables(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{"parts", `{"word": "dictionary", "hyphenations": [{"parts": ["dic", "tion", "ar", "y"]}]}`,
+			[]string{"dic", "tion", "ar", "y"}},
+		{"first matching candidate", `{"word": "Colour", "hyphenations": [` +
+			`{"parts": ["col", "or"]}, {"parts": []}, {"parts": ["col", "our"]}, {"parts": ["co", "lour"]}]}`,
+			[]string{"col", "our"}},
+		{"older string form", `{"word": "été", "hyphenation": ["é‧té"]}`, []string{"é", "té"}},
+		{"parts before strings", `{"word": "cater", "hyphenation": ["ca‧ter"], "hyphenations": [{"parts": ["cat", "er"]}]}`,
+			[]string{"cat", "er"}},
+		{"no matching candidate", `{"word": "cat", "hyphenations": [{"parts": ["kat"]}]}`, nil},
+		{"none", `{"word": "cat"}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decode(t, tt.line).Syllables; !slices.Equal(got, tt.want) {
+				t.Errorf("syllables = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeSounds(t *testing.T) {
+	e := decode(t, `{"word": "cat", "lang_code": "en", "sounds": [`+
+		`{"ipa": "/kæt/", "tags": ["UK", "US"]}, `+
+		`{"rhymes": "-æt"}, `+
+		`{"audio": "en-us-cat.ogg", "tags": ["US"]}]}`)
+	want := []extract.Sound{
+		{IPA: "/kæt/", Tags: []string{"UK", "US"}},
+		{Rhyme: "-æt"},
+		{Audio: "en-us-cat.ogg", Tags: []string{"US"}},
+	}
+	if len(e.Sounds) != len(want) {
+		t.Fatalf("sounds = %+v, want %+v", e.Sounds, want)
+	}
+	for i := range want {
+		got := e.Sounds[i]
+		if got.IPA != want[i].IPA || got.Rhyme != want[i].Rhyme || got.Audio != want[i].Audio ||
+			!slices.Equal(got.Tags, want[i].Tags) {
+			t.Errorf("sound %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package wordlist
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/pbojar/dictextract/internal/extract"
+)
+
+// TSVSource is an extract.Source reading a tab separated dictionary, the export
+// format of many open dictionaries, with one sense per line in the columns:
+//
+//	word	pos	gloss	tags
+//
+// where tags is a comma separated list. Trailing columns may be left out, and
+// lines without a word are skipped. Consecutive lines with the same word and pos
+// form one entry.
+type TSVSource struct {
+	lang    string
+	lines   *lineReader
+	pending *extract.Entry // Entry started by the last line read, if any
+}
+
+// NewTSVSource opens the dictionary at path, or stdin if path is "-", whose
+// words are in the language with code lang.
+func NewTSVSource(path, lang string) (*TSVSource, error) {
+	lines, err := newLineReader(path)
+	if err != nil {
+		return nil, err
+	}
+	return &TSVSource{lang: lang, lines: lines}, nil
+}
+
+// Next returns the next entry of the dictionary, or io.EOF at its end.
+func (s *TSVSource) Next() (extract.Entry, error) {
+	for {
+		line, err := s.lines.next()
+		if errors.Is(err, io.EOF) && s.pending != nil {
+			e := *s.pending
+			s.pending = nil
+			return e, nil
+		}
+		if err != nil {
+			return extract.Entry{}, err
+		}
+
+		// Trim each column rather than the line, so empty leading columns are kept
+		cols := strings.Split(line, "\t")
+		for len(cols) < 4 {
+			cols = append(cols, "")
+		}
+		word, pos, gloss, tags := strings.TrimSpace(cols[0]), strings.TrimSpace(cols[1]), strings.TrimSpace(cols[2]), strings.TrimSpace(cols[3])
+		if word == "" {
+			continue
+		}
+
+		var sense []extract.Sense
+		if gloss != "" {
+			sense = []extract.Sense{{Gloss: gloss}}
+			for _, tag := range strings.Split(tags, ",") {
+				// Tags may be separated by spaces too, e.g. "rare, archaic"
+				if tag = strings.TrimSpace(tag); tag != "" {
+					sense[0].Tags = append(sense[0].Tags, tag)
+				}
+			}
+		}
+
+		// Add the sense to the pending entry if it has the same word and pos
+		if s.pending != nil && s.pending.Word == word && s.pending.Pos == pos {
+			s.pending.Senses = append(s.pending.Senses, sense...)
+			continue
+		}
+		prev := s.pending
+		s.pending = &extract.Entry{Word: word, Lang: s.lang, Pos: pos, Senses: sense}
+		if prev != nil {
+			return *prev, nil
+		}
+	}
+}
+
+// SensesOptional returns true, since glosses may be left out of the dictionary.
+func (s *TSVSource) SensesOptional() bool {
+	return true
+}
+
+// Close closes the dictionary.
+func (s *TSVSource) Close() error {
+	return s.lines.close()
+}
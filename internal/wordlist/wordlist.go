@@ -0,0 +1,107 @@
+package wordlist
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pbojar/dictextract/internal/extract"
+)
+
+// lineReader reads the lines of a text file, which is decompressed if it is
+// gzipped. Blank lines and lines starting with '#', after any leading
+// whitespace, are skipped.
+type lineReader struct {
+	file     *os.File
+	gzReader *gzip.Reader
+	scanner  *bufio.Scanner
+}
+
+// newLineReader opens the file at path, or stdin if path is "-".
+func newLineReader(path string) (*lineReader, error) {
+	file := os.Stdin
+	if path != "-" {
+		var err error
+		file, err = os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	r := &lineReader{file: file}
+
+	// Detect gzip by its magic number, since stdin has no file name
+	buffered := bufio.NewReader(file)
+	var reader io.Reader = buffered
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		r.gzReader, err = gzip.NewReader(buffered)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		reader = r.gzReader
+	}
+	r.scanner = bufio.NewScanner(reader)
+	return r, nil
+}
+
+// next returns the next line, untrimmed since whitespace may separate columns,
+// or io.EOF at the end of the file.
+func (r *lineReader) next() (string, error) {
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		return line, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", io.EOF
+}
+
+func (r *lineReader) close() error {
+	if r.gzReader != nil {
+		r.gzReader.Close()
+	}
+	return r.file.Close()
+}
+
+// Source is an extract.Source reading a plain word list with one word per line.
+// Its entries have no part of speech or senses, which are optional to filters.
+type Source struct {
+	lang  string
+	lines *lineReader
+}
+
+// NewSource opens the word list at path, or stdin if path is "-", whose words
+// are in the language with code lang.
+func NewSource(path, lang string) (*Source, error) {
+	lines, err := newLineReader(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Source{lang: lang, lines: lines}, nil
+}
+
+// Next returns an entry for the next word of the list, or io.EOF at its end.
+func (s *Source) Next() (extract.Entry, error) {
+	line, err := s.lines.next()
+	if err != nil {
+		return extract.Entry{}, err
+	}
+	return extract.Entry{Word: strings.TrimSpace(line), Lang: s.lang}, nil
+}
+
+// SensesOptional returns true, since words of a word list have no senses.
+func (s *Source) SensesOptional() bool {
+	return true
+}
+
+// Close closes the word list.
+func (s *Source) Close() error {
+	return s.lines.close()
+}
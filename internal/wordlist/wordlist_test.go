@@ -0,0 +1,139 @@
+package wordlist
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/pbojar/dictextract/internal/extract"
+)
+
+// writeTestFile writes content to a file named name in a temporary directory,
+// gzipped if zip is set, and returns its path.
+func writeTestFile(t *testing.T, name, content string, zip bool) string {
+	t.Helper()
+	data := []byte(content)
+	if zip {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		data = buf.Bytes()
+	}
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// readAll returns every entry of src.
+func readAll(t *testing.T, src extract.Source) []extract.Entry {
+	t.Helper()
+	defer src.Close()
+	entries := []extract.Entry{}
+	for {
+		e, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			return entries
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		entries = append(entries, e)
+	}
+}
+
+const testList = `# A comment
+cat
+  dog
+
+	# An indented comment
+été
+`
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		zip  bool
+	}{
+		{"plain", "words.txt", false},
+		{"gzipped", "words.txt.gz", true},
+		{"gzipped without extension", "words.txt", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := NewSource(writeTestFile(t, tt.file, testList, tt.zip), "fr")
+			if err != nil {
+				t.Fatalf("NewSource() error = %v", err)
+			}
+			got := []string{}
+			for _, e := range readAll(t, src) {
+				if e.Lang != "fr" || e.Pos != "" || len(e.Senses) != 0 {
+					t.Errorf("entry %+v, want only a word in fr", e)
+				}
+				got = append(got, e.Word)
+			}
+			if want := []string{"cat", "dog", "été"}; !slices.Equal(got, want) {
+				t.Errorf("words = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+const testTSV = "cat\tnoun\tA small feline.\tcommon, pet ,\n" +
+	"cat\tnoun\tA jazz musician.\tslang\n" +
+	"cat\tverb\tTo vomit.\n" +
+	"\tnoun\tA row without a word.\n" +
+	"# A comment between senses\n" +
+	"cat\tverb\tTo hoist an anchor. \t nautical \n" +
+	"dog\tnoun\n" +
+	"cat\tnoun\tA catamaran.\n"
+
+func TestTSVSource(t *testing.T) {
+	src, err := NewTSVSource(writeTestFile(t, "dict.tsv", testTSV, false), "en")
+	if err != nil {
+		t.Fatalf("NewTSVSource() error = %v", err)
+	}
+	got := readAll(t, src)
+
+	type sense struct {
+		gloss string
+		tags  []string
+	}
+	want := []struct {
+		word   string
+		pos    string
+		senses []sense
+	}{
+		{"cat", "noun", []sense{{"A small feline.", []string{"common", "pet"}}, {"A jazz musician.", []string{"slang"}}}},
+		{"cat", "verb", []sense{{"To vomit.", nil}, {"To hoist an anchor.", []string{"nautical"}}}},
+		{"dog", "noun", nil},
+		{"cat", "noun", []sense{{"A catamaran.", nil}}},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries %+v, want %d", len(got), got, len(want))
+	}
+	for i, w := range want {
+		e := got[i]
+		if e.Word != w.word || e.Pos != w.pos || e.Lang != "en" || len(e.Senses) != len(w.senses) {
+			t.Errorf("entry %d = %+v, want %s (%s) with %d senses", i, e, w.word, w.pos, len(w.senses))
+			continue
+		}
+		for j, s := range w.senses {
+			if e.Senses[j].Gloss != s.gloss || !slices.Equal(e.Senses[j].Tags, s.tags) {
+				t.Errorf("entry %d sense %d = %+v, want %+v", i, j, e.Senses[j], s)
+			}
+		}
+	}
+}
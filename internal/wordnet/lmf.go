@@ -0,0 +1,74 @@
+package wordnet
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// lmfEntry is a LexicalEntry of a WN-LMF file: a word in one part of speech.
+type lmfEntry struct {
+	Lemma struct {
+		WrittenForm  string `xml:"writtenForm,attr"`
+		PartOfSpeech string `xml:"partOfSpeech,attr"`
+	} `xml:"Lemma"`
+	Forms []struct {
+		WrittenForm string `xml:"writtenForm,attr"`
+	} `xml:"Form"`
+	Senses []lmfSense `xml:"Sense"`
+}
+
+// lmfSense links a lmfEntry to one of its synsets.
+type lmfSense struct {
+	ID        string        `xml:"id,attr"`
+	Synset    string        `xml:"synset,attr"`
+	Relations []lmfRelation `xml:"SenseRelation"`
+}
+
+// lmfSynset is a set of senses sharing a meaning.
+type lmfSynset struct {
+	ID          string        `xml:"id,attr"`
+	Definitions []string      `xml:"Definition"`
+	Relations   []lmfRelation `xml:"SynsetRelation"`
+}
+
+type lmfRelation struct {
+	RelType string `xml:"relType,attr"`
+	Target  string `xml:"target,attr"`
+}
+
+// posNames maps WordNet part of speech codes to the names Wiktionary uses, so
+// filter configs apply to both. Adjective satellites are adjectives.
+var posNames = map[string]string{
+	"n": "noun",
+	"v": "verb",
+	"a": "adj",
+	"s": "adj",
+	"r": "adv",
+	"c": "conj",
+	"p": "prep",
+}
+
+// posName returns the name of the WordNet part of speech code pos, or pos itself
+// if it has none.
+func posName(pos string) string {
+	if name, ok := posNames[pos]; ok {
+		return name
+	}
+	return pos
+}
+
+// lexiconLang returns the language attribute of a Lexicon element.
+func lexiconLang(start xml.StartElement) string {
+	return strings.ToLower(lexiconAttr(start, "language"))
+}
+
+// lexiconAttr returns the attribute name of a Lexicon element, or "" if it has
+// none.
+func lexiconAttr(start xml.StartElement, name string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
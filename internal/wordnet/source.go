@@ -0,0 +1,199 @@
+package wordnet
+
+import (
+	"compress/gzip"
+	"encoding/xml"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pbojar/dictextract/internal/extract"
+)
+
+// Source is an extract.Source reading a WordNet in the WN-LMF XML format, such as
+// Open English WordNet, which may be gzipped (.gz). Each lexical entry becomes an
+// entry whose senses are glossed by the definitions of their synsets. Synonyms
+// share a synset, while hypernyms, antonyms and other relations are kept as the
+// synset and sense relations of the senses.
+//
+// Since synsets follow the entries that refer to them, the file is read twice:
+// once by NewSource, keeping only the synsets, then one lexical entry at a time
+// by Next. Source is an extract.LicensedSource, since WN-LMF lexicons state
+// their license.
+type Source struct {
+	file     *lmfFile
+	lang     string // Of the lexicon being read
+	synsets  map[string]lmfSynset
+	licenses []string // Of the lexicons, without repeats
+}
+
+// lmfFile is a WN-LMF file open for decoding, decompressed if gzipped.
+type lmfFile struct {
+	file     *os.File
+	gzReader *gzip.Reader
+	decoder  *xml.Decoder
+}
+
+func openLMF(path string) (*lmfFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	f := &lmfFile{file: file}
+
+	var reader io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		f.gzReader, err = gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		reader = f.gzReader
+	}
+	f.decoder = xml.NewDecoder(reader)
+	return f, nil
+}
+
+// nextStart returns the next start element of the file, or io.EOF at its end.
+func (f *lmfFile) nextStart() (xml.StartElement, error) {
+	for {
+		token, err := f.decoder.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start, nil
+		}
+	}
+}
+
+func (f *lmfFile) close() error {
+	if f.gzReader != nil {
+		f.gzReader.Close()
+	}
+	return f.file.Close()
+}
+
+// NewSource reads the synsets and licenses of the WordNet at path, and opens it
+// again for Next to read its entries.
+func NewSource(path string) (*Source, error) {
+	f, err := openLMF(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.close()
+
+	src := &Source{synsets: make(map[string]lmfSynset)}
+	for {
+		start, err := f.nextStart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch start.Name.Local {
+		case "Lexicon":
+			license := lexiconAttr(start, "license")
+			if license != "" && !slices.Contains(src.licenses, license) {
+				src.licenses = append(src.licenses, license)
+			}
+		case "LexicalEntry":
+			if err := f.decoder.Skip(); err != nil {
+				return nil, err
+			}
+		case "Synset":
+			var synset lmfSynset
+			if err := f.decoder.DecodeElement(&synset, &start); err != nil {
+				return nil, err
+			}
+			// Only the first definition is used
+			if len(synset.Definitions) > 1 {
+				synset.Definitions = synset.Definitions[:1]
+			}
+			src.synsets[synset.ID] = synset
+		}
+	}
+
+	src.file, err = openLMF(path)
+	if err != nil {
+		return nil, err
+	}
+	return src, nil
+}
+
+// newEntry normalizes the lexical entry e of the language lang.
+func newEntry(e lmfEntry, lang string, synsets map[string]lmfSynset) extract.Entry {
+	entry := extract.Entry{
+		Word: e.Lemma.WrittenForm,
+		Lang: lang,
+		Pos:  posName(e.Lemma.PartOfSpeech),
+	}
+	// WordNet gives proper nouns, e.g. Paris, the pos of any noun, so they get
+	// Wiktionary's pos for them, which filter configs leave out by default
+	if first, _ := utf8.DecodeRuneInString(entry.Word); entry.Pos == "noun" && unicode.IsUpper(first) {
+		entry.Pos = "name"
+	}
+	for _, form := range e.Forms {
+		entry.Forms = append(entry.Forms, extract.Form{Form: form.WrittenForm})
+	}
+	for _, sense := range e.Senses {
+		synset := synsets[sense.Synset]
+		s := extract.Sense{
+			Key:       sense.ID,
+			Synset:    &extract.Synset{Key: sense.Synset, Relations: relations(synset.Relations)},
+			Relations: relations(sense.Relations),
+		}
+		if len(synset.Definitions) > 0 {
+			s.Gloss = strings.TrimSpace(synset.Definitions[0])
+		}
+		entry.Senses = append(entry.Senses, s)
+	}
+	return entry
+}
+
+func relations(rels []lmfRelation) []extract.Relation {
+	out := []extract.Relation{}
+	for _, rel := range rels {
+		out = append(out, extract.Relation{Type: rel.RelType, Target: rel.Target})
+	}
+	return out
+}
+
+// Next returns the next entry of the WordNet, or io.EOF after the last one.
+func (s *Source) Next() (extract.Entry, error) {
+	for {
+		start, err := s.file.nextStart()
+		if err != nil {
+			return extract.Entry{}, err
+		}
+		switch start.Name.Local {
+		case "Lexicon":
+			s.lang = lexiconLang(start)
+		case "LexicalEntry":
+			var e lmfEntry
+			if err := s.file.decoder.DecodeElement(&e, &start); err != nil {
+				return extract.Entry{}, err
+			}
+			return newEntry(e, s.lang, s.synsets), nil
+		case "Synset":
+			if err := s.file.decoder.Skip(); err != nil {
+				return extract.Entry{}, err
+			}
+		}
+	}
+}
+
+// License returns the licenses of the lexicons of the WordNet, usually given as
+// URLs, separated by commas.
+func (s *Source) License() string {
+	return strings.Join(s.licenses, ", ")
+}
+
+// Close closes the WordNet.
+func (s *Source) Close() error {
+	return s.file.close()
+}
@@ -0,0 +1,153 @@
+package wordnet
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/pbojar/dictextract/internal/extract"
+)
+
+const testLMF = `<?xml version="1.0" encoding="UTF-8"?>
+<LexicalResource xmlns:dc="https://globalwordnet.github.io/schemas/dc/">
+  <Lexicon id="test" label="Test WordNet" language="EN" email="a@b.c"
+           license="https://creativecommons.org/licenses/by/4.0/" version="1.0">
+    <LexicalEntry id="test-cat-n">
+      <Lemma writtenForm="cat" partOfSpeech="n"/>
+      <Form writtenForm="cats"/>
+      <Sense id="test-cat-n-1" synset="test-1-n">
+        <SenseRelation relType="derivation" target="test-catty-s-1"/>
+      </Sense>
+      <Sense id="test-cat-n-2" synset="test-2-n"/>
+    </LexicalEntry>
+    <LexicalEntry id="test-catty-s">
+      <Lemma writtenForm="catty" partOfSpeech="s"/>
+      <Sense id="test-catty-s-1" synset="test-3-s"/>
+    </LexicalEntry>
+    <LexicalEntry id="test-Paris-n">
+      <Lemma writtenForm="Paris" partOfSpeech="n"/>
+      <Sense id="test-Paris-n-1" synset="test-5-n"/>
+    </LexicalEntry>
+    <LexicalEntry id="test-Victorian-a">
+      <Lemma writtenForm="Victorian" partOfSpeech="a"/>
+      <Sense id="test-Victorian-a-1" synset="test-6-a"/>
+    </LexicalEntry>
+    <Synset id="test-1-n" partOfSpeech="n">
+      <Definition> a small domesticated feline </Definition>
+      <Definition>a second definition</Definition>
+      <SynsetRelation relType="hypernym" target="test-4-n"/>
+    </Synset>
+    <Synset id="test-2-n" partOfSpeech="n"/>
+    <Synset id="test-3-s" partOfSpeech="s">
+      <Definition>subtly cruel</Definition>
+    </Synset>
+  </Lexicon>
+  <Lexicon id="other" label="Other" language="fr" email="a@b.c"
+           license="https://creativecommons.org/licenses/by/4.0/" version="1.0">
+    <LexicalEntry id="other-chat-n">
+      <Lemma writtenForm="chat" partOfSpeech="n"/>
+      <Sense id="other-chat-n-1" synset="test-1-n"/>
+    </LexicalEntry>
+  </Lexicon>
+</LexicalResource>
+`
+
+// writeTestLMF writes testLMF to a file named name in a temporary directory,
+// gzipped if name ends in .gz, and returns its path.
+func writeTestLMF(t *testing.T, name string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var w io.Writer = file
+	if filepath.Ext(name) == ".gz" {
+		zw := gzip.NewWriter(file)
+		defer zw.Close()
+		w = zw
+	}
+	if _, err := io.WriteString(w, testLMF); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSource(t *testing.T) {
+	for _, name := range []string{"wn.xml", "wn.xml.gz"} {
+		t.Run(name, func(t *testing.T) {
+			src, err := NewSource(writeTestLMF(t, name))
+			if err != nil {
+				t.Fatalf("NewSource() error = %v", err)
+			}
+			defer src.Close()
+
+			if want := "https://creativecommons.org/licenses/by/4.0/"; src.License() != want {
+				t.Errorf("License() = %q, want %q", src.License(), want)
+			}
+
+			entries := []extract.Entry{}
+			for {
+				e, err := src.Next()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Next() error = %v", err)
+				}
+				entries = append(entries, e)
+			}
+			if len(entries) != 5 {
+				t.Fatalf("got %d entries %+v, want 5", len(entries), entries)
+			}
+
+			cat := entries[0]
+			if cat.Word != "cat" || cat.Lang != "en" || cat.Pos != "noun" {
+				t.Errorf("entry 0 = %s (%s, %s), want cat (en, noun)", cat.Word, cat.Lang, cat.Pos)
+			}
+			if len(cat.Forms) != 1 || cat.Forms[0].Form != "cats" {
+				t.Errorf("forms of cat = %+v, want [cats]", cat.Forms)
+			}
+			if len(cat.Senses) != 2 {
+				t.Fatalf("cat has %d senses, want 2", len(cat.Senses))
+			}
+			sense := cat.Senses[0]
+			if sense.Key != "test-cat-n-1" || sense.Gloss != "a small domesticated feline" {
+				t.Errorf("sense 0 of cat = %q %q, want the first definition of its synset", sense.Key, sense.Gloss)
+			}
+			if sense.Synset == nil || sense.Synset.Key != "test-1-n" ||
+				!slices.Equal(sense.Synset.Relations, []extract.Relation{{Type: "hypernym", Target: "test-4-n"}}) {
+				t.Errorf("synset of sense 0 of cat = %+v, want test-1-n with a hypernym", sense.Synset)
+			}
+			if !slices.Equal(sense.Relations, []extract.Relation{{Type: "derivation", Target: "test-catty-s-1"}}) {
+				t.Errorf("relations of sense 0 of cat = %+v, want a derivation", sense.Relations)
+			}
+			if cat.Senses[1].Gloss != "" || cat.Senses[1].Synset.Key != "test-2-n" {
+				t.Errorf("sense 1 of cat = %+v, want test-2-n without a gloss", cat.Senses[1])
+			}
+
+			catty := entries[1]
+			if catty.Pos != "adj" || len(catty.Senses) != 1 || catty.Senses[0].Gloss != "subtly cruel" {
+				t.Errorf("entry 1 = %+v, want the satellite adjective catty", catty)
+			}
+
+			if paris := entries[2]; paris.Word != "Paris" || paris.Pos != "name" {
+				t.Errorf("entry 2 = %+v, want the proper noun Paris", paris)
+			}
+			if victorian := entries[3]; victorian.Word != "Victorian" || victorian.Pos != "adj" {
+				t.Errorf("entry 3 = %+v, want the adjective Victorian", victorian)
+			}
+
+			chat := entries[4]
+			if chat.Word != "chat" || chat.Lang != "fr" || chat.Senses[0].Gloss != "a small domesticated feline" {
+				t.Errorf("entry 4 = %+v, want chat in fr sharing cat's synset", chat)
+			}
+		})
+	}
+}
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	_ "github.com/lib/pq"
 	"github.com/pbojar/dictextract/internal/config"
@@ -29,18 +32,25 @@ func main() {
 		fmt.Printf("error reading config: %v\n", err)
 	}
 
-	// Connect to current DB
+	// Connect to current DB, if any, since some commands run without one
 	// TODO: Support changing DBs
-	db, err := sql.Open("postgres", *cfg.DBURL)
-	if err != nil {
-		fmt.Printf("error opening db: %v\n", err)
+	var db *sql.DB
+	var dbQueries *database.Queries
+	if cfg.DBURL != nil {
+		db, err = sql.Open("postgres", *cfg.DBURL)
+		if err != nil {
+			fmt.Printf("error opening db: %v\n", err)
+			db = nil
+		} else {
+			dbQueries = database.New(db)
+		}
 	}
-	dbQueries := database.New(db)
 
 	// Initialize app state
 	s := state{
-		db:  dbQueries,
-		cfg: &cfg,
+		db:   dbQueries,
+		conn: db,
+		cfg:  &cfg,
 	}
 
 	// Make commands
@@ -52,8 +62,15 @@ func main() {
 		// Exit if command does not exist
 		log.Fatalf("Unknown command '%s'", user_cmd_name)
 	} else {
-		// Execute command callback function with user args
-		err := cliComm.callback(&s, user_cmd_args...)
+		// Execute command callback function with user args, cancelling its
+		// context on Ctrl-C so it can stop cleanly. A second Ctrl-C kills it.
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-ctx.Done()
+			stop()
+		}()
+		err := cliComm.callback(ctx, &s, user_cmd_args...)
+		stop()
 		if err != nil {
 			log.Fatal(err)
 		}
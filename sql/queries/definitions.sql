@@ -1,12 +1,25 @@
--- name: CreateDefinition :one
-INSERT INTO definitions (id, word_id, pos_id, "definition")
-VALUES (
-    DEFAULT,
-    $1,
-    $2,
-    $3
-)
-RETURNING *;
+-- name: GetDefinitionsByWord :many
+SELECT parts_of_speech.pos, definitions.etymology_number, definitions.sense_index, definitions."definition"
+FROM definitions
+JOIN words ON words.id = definitions.word_id
+JOIN parts_of_speech ON parts_of_speech.id = definitions.pos_id
+WHERE words.word = $1 AND words.lang = $2
+ORDER BY definitions.etymology_number, definitions.pos_id, definitions.sense_index;
 
--- name: DefinitionExists :one
-SELECT EXISTS(SELECT 1 FROM definitions WHERE word_id=$1 AND pos_id=$2);
+-- name: GetExistingDefinitionKeys :many
+SELECT DISTINCT definitions.word_id, definitions.pos_id, definitions.etymology_number
+FROM definitions
+JOIN unnest(@word_ids::int[], @pos_ids::int[], @etymology_numbers::int[]) AS input(word_id, pos_id, etymology_number)
+ON definitions.word_id = input.word_id AND definitions.pos_id = input.pos_id
+AND definitions.etymology_number = input.etymology_number;
+
+-- name: CreateDefinitions :many
+INSERT INTO definitions (word_id, pos_id, "definition", etymology_number, sense_index, import_run_id)
+SELECT input.*, sqlc.narg(import_run_id)::int FROM unnest(
+    @word_ids::int[],
+    @pos_ids::int[],
+    @definitions::text[],
+    @etymology_numbers::int[],
+    @sense_indexes::int[]
+) AS input
+RETURNING id, word_id, pos_id, etymology_number, sense_index;
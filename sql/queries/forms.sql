@@ -0,0 +1,18 @@
+-- name: GetLemmasByForm :many
+SELECT word_forms.lemma, parts_of_speech.pos, word_forms.tags
+FROM word_forms
+JOIN parts_of_speech ON parts_of_speech.id = word_forms.pos_id
+WHERE word_forms.form = $1 AND word_forms.lang = $2 AND word_forms.lemma <> word_forms.form
+ORDER BY word_forms.lemma, word_forms.id;
+
+-- name: AddWordForms :exec
+INSERT INTO word_forms (form, lemma, lang, pos_id, tags)
+SELECT input.form, input.lemma, input.lang, input.pos_id, string_to_array(input.tags, chr(31))
+FROM unnest(
+    @forms::text[],
+    @lemmas::text[],
+    @langs::text[],
+    @pos_ids::int[],
+    @tags::text[]
+) AS input(form, lemma, lang, pos_id, tags)
+ON CONFLICT DO NOTHING;
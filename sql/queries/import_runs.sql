@@ -0,0 +1,35 @@
+-- name: CreateImportRun :one
+INSERT INTO import_runs (file_path, file_name, file_hash, source_id, langs, dump_date, license, filter_config)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetLastImportRun :one
+SELECT import_runs.id, sources.name AS source_name, import_runs.file_hash, import_runs.langs,
+    import_runs.filter_config, import_runs.checkpoint, import_runs.completed
+FROM import_runs
+JOIN sources ON sources.id = import_runs.source_id
+WHERE import_runs.file_path = $1
+ORDER BY import_runs.id DESC
+LIMIT 1;
+
+-- name: GetImportRunsByWord :many
+SELECT import_runs.id, sources.name AS source_name, sources.url, import_runs.file_name, import_runs.dump_date,
+    import_runs.license, import_runs.filter_config, import_runs.started_at,
+    word_import_runs.added AS added_word,
+    COUNT(definitions.id) AS definitions
+FROM words
+JOIN word_import_runs ON word_import_runs.word_id = words.id
+JOIN import_runs ON import_runs.id = word_import_runs.import_run_id
+JOIN sources ON sources.id = import_runs.source_id
+LEFT JOIN definitions ON definitions.word_id = words.id AND definitions.import_run_id = import_runs.id
+WHERE words.word = $1 AND words.lang = $2
+GROUP BY import_runs.id, sources.name, sources.url, word_import_runs.added
+ORDER BY import_runs.id;
+
+-- name: UpdateImportRunCheckpoint :exec
+UPDATE import_runs SET checkpoint = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: CompleteImportRun :exec
+UPDATE import_runs SET checkpoint = $2, completed = true, updated_at = NOW()
+WHERE id = $1;
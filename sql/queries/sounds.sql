@@ -0,0 +1,49 @@
+-- name: GetRhymesByWord :many
+SELECT DISTINCT pronunciations.rhyme
+FROM pronunciations
+JOIN words ON words.id = pronunciations.word_id
+WHERE words.word = $1 AND words.lang = $2 AND pronunciations.rhyme <> ''
+ORDER BY pronunciations.rhyme;
+
+-- name: GetWordsBySounds :many
+SELECT word FROM words
+WHERE lang = @lang
+AND (@rhyme::text = '' OR EXISTS (
+    SELECT 1 FROM pronunciations
+    WHERE pronunciations.word_id = words.id AND pronunciations.rhyme = @rhyme
+))
+AND (@syllables::int = 0 OR EXISTS (
+    SELECT 1 FROM hyphenations
+    WHERE hyphenations.word_id = words.id AND hyphenations.syllables = @syllables
+))
+ORDER BY word COLLATE "C" ASC;
+
+-- name: GetPronunciationsByLang :many
+SELECT words.word, pronunciations.ipa, pronunciations.rhyme
+FROM pronunciations
+JOIN words ON words.id = pronunciations.word_id
+WHERE words.lang = $1 AND (pronunciations.ipa <> '' OR pronunciations.rhyme <> '')
+ORDER BY pronunciations.id;
+
+-- name: GetHyphenationsByLang :many
+SELECT words.word, hyphenations.hyphenation, hyphenations.syllables
+FROM hyphenations
+JOIN words ON words.id = hyphenations.word_id
+WHERE words.lang = $1;
+
+-- name: AddPronunciations :exec
+INSERT INTO pronunciations (word_id, ipa, rhyme, audio, tags)
+SELECT input.word_id, input.ipa, input.rhyme, input.audio, string_to_array(input.tags, chr(31))
+FROM unnest(
+    @word_ids::int[],
+    @ipas::text[],
+    @rhymes::text[],
+    @audios::text[],
+    @tags::text[]
+) AS input(word_id, ipa, rhyme, audio, tags)
+ON CONFLICT DO NOTHING;
+
+-- name: AddHyphenations :exec
+INSERT INTO hyphenations (word_id, hyphenation, syllables)
+SELECT * FROM unnest(@word_ids::int[], @hyphenations::text[], @syllables::int[])
+ON CONFLICT DO NOTHING;
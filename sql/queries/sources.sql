@@ -0,0 +1,5 @@
+-- name: UpsertSource :one
+INSERT INTO sources (name)
+VALUES ($1)
+ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+RETURNING *;
@@ -0,0 +1,43 @@
+-- name: GetRelatedWords :many
+SELECT related.rel_type, synsets.synset_key, synsets.definition, words.word
+FROM (
+    SELECT 'synonym' AS rel_type, sense.synset_key, member.word_id
+    FROM word_senses AS sense
+    JOIN word_senses AS member ON member.synset_key = sense.synset_key AND member.word_id <> sense.word_id
+    WHERE sense.word_id = $1
+    UNION
+    SELECT synset_relations.rel_type, sense.synset_key, member.word_id
+    FROM word_senses AS sense
+    JOIN synset_relations ON synset_relations.synset_key = sense.synset_key
+    JOIN word_senses AS member ON member.synset_key = synset_relations.target_key
+    WHERE sense.word_id = $1
+    UNION
+    SELECT sense_relations.rel_type, sense.synset_key, target.word_id
+    FROM word_senses AS sense
+    JOIN sense_relations ON sense_relations.sense_key = sense.sense_key
+    JOIN word_senses AS target ON target.sense_key = sense_relations.target_key
+    WHERE sense.word_id = $1
+) AS related
+JOIN synsets ON synsets.synset_key = related.synset_key
+JOIN words ON words.id = related.word_id
+ORDER BY synsets.id, related.rel_type, words.word COLLATE "C";
+
+-- name: AddSynsets :exec
+INSERT INTO synsets (synset_key, pos_id, definition)
+SELECT * FROM unnest(@synset_keys::text[], @pos_ids::int[], @definitions::text[])
+ON CONFLICT DO NOTHING;
+
+-- name: AddWordSenses :exec
+INSERT INTO word_senses (sense_key, word_id, synset_key)
+SELECT * FROM unnest(@sense_keys::text[], @word_ids::int[], @synset_keys::text[])
+ON CONFLICT DO NOTHING;
+
+-- name: AddSynsetRelations :exec
+INSERT INTO synset_relations (synset_key, target_key, rel_type)
+SELECT * FROM unnest(@synset_keys::text[], @target_keys::text[], @rel_types::text[])
+ON CONFLICT DO NOTHING;
+
+-- name: AddSenseRelations :exec
+INSERT INTO sense_relations (sense_key, target_key, rel_type)
+SELECT * FROM unnest(@sense_keys::text[], @target_keys::text[], @rel_types::text[])
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,18 @@
+-- name: UpsertTags :many
+WITH input AS (
+    SELECT DISTINCT unnest(@tags::text[]) AS tag
+), inserted AS (
+    INSERT INTO tags (tag)
+    SELECT tag FROM input
+    ON CONFLICT DO NOTHING
+    RETURNING id, tag
+)
+SELECT inserted.id, inserted.tag FROM inserted
+UNION ALL
+SELECT tags.id, tags.tag FROM tags
+JOIN input ON input.tag = tags.tag;
+
+-- name: AddDefinitionTags :exec
+INSERT INTO definition_tags (definition_id, tag_id)
+SELECT * FROM unnest(@definition_ids::int[], @tag_ids::int[])
+ON CONFLICT DO NOTHING;
@@ -1,13 +1,50 @@
--- name: CreateWord :one
-INSERT INTO words (id, word)
-VALUES (
-    DEFAULT, 
-    $1
-) 
-RETURNING *;
-
 -- name: GetIDByWord :one
-SELECT id FROM words WHERE word=$1;
+SELECT id FROM words WHERE word=$1 AND lang=$2;
 
 -- name: GetWordsWithLenInRangeSorted :many
-SELECT word FROM words WHERE CHAR_LENGTH(word) BETWEEN @minLen AND @maxLen ORDER BY word ASC;
+-- A word is left out only if every one of its definitions has an excluded tag,
+-- so words with a single rare or obsolete sense are kept, as are words without
+-- definitions.
+-- Unless forms are included, words that are only inflected forms of other
+-- words are left out. A form that is also the lemma of some form, e.g. "saw"
+-- as the past of "see" and a noun with the plural "saws", is a word of its
+-- own and kept, as is a form whose lemma is itself.
+SELECT word FROM words
+WHERE CHAR_LENGTH(word) BETWEEN @minLen AND @maxLen AND lang=@lang
+AND NOT (
+    EXISTS (SELECT 1 FROM definitions WHERE definitions.word_id = words.id)
+    AND NOT EXISTS (
+        SELECT 1 FROM definitions
+        WHERE definitions.word_id = words.id AND NOT EXISTS (
+            SELECT 1 FROM definition_tags
+            JOIN tags ON tags.id = definition_tags.tag_id
+            WHERE definition_tags.definition_id = definitions.id AND tags.tag = ANY(@excludedTags::text[])
+        )
+    )
+)
+AND (@includeForms::boolean OR NOT EXISTS (
+    SELECT 1 FROM word_forms AS f
+    WHERE f.form = words.word AND f.lang = words.lang AND f.lemma <> f.form
+    AND NOT EXISTS (SELECT 1 FROM word_forms AS l WHERE l.lemma = words.word AND l.lang = words.lang)
+))
+ORDER BY word COLLATE "C" ASC;
+
+-- name: UpsertWords :many
+WITH input AS (
+    SELECT * FROM unnest(@words::text[], @langs::text[]) AS input(word, lang)
+), inserted AS (
+    INSERT INTO words (word, lang)
+    SELECT word, lang FROM input
+    ON CONFLICT DO NOTHING
+    RETURNING id, word, lang
+)
+SELECT inserted.id, inserted.word, inserted.lang, true AS created FROM inserted
+UNION ALL
+SELECT words.id, words.word, words.lang, false AS created FROM words
+JOIN input ON input.word = words.word AND input.lang = words.lang;
+
+-- name: AddWordImportRuns :exec
+INSERT INTO word_import_runs (word_id, import_run_id, added)
+SELECT input.word_id, @import_run_id::int, input.added
+FROM unnest(@word_ids::int[], @added::boolean[]) AS input(word_id, added)
+ON CONFLICT DO NOTHING;
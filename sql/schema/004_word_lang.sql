@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE words ADD COLUMN lang TEXT NOT NULL DEFAULT 'en';
+ALTER TABLE words DROP CONSTRAINT words_word_key;
+ALTER TABLE words ADD CONSTRAINT uc_word_lang UNIQUE (word, lang);
+
+-- +goose Down
+ALTER TABLE words DROP CONSTRAINT uc_word_lang;
+ALTER TABLE words ADD CONSTRAINT words_word_key UNIQUE (word);
+ALTER TABLE words DROP COLUMN lang;
@@ -0,0 +1,23 @@
+-- +goose Up
+CREATE TABLE tags(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    tag TEXT UNIQUE NOT NULL
+);
+
+CREATE TABLE definition_tags(
+    definition_id INT NOT NULL,
+    CONSTRAINT fk_definition_id
+    FOREIGN KEY (definition_id)
+    REFERENCES definitions(id)
+    ON DELETE CASCADE,
+    tag_id INT NOT NULL,
+    CONSTRAINT fk_tag_id
+    FOREIGN KEY (tag_id)
+    REFERENCES tags(id)
+    ON DELETE CASCADE,
+    PRIMARY KEY (definition_id, tag_id)
+);
+
+-- +goose Down
+DROP TABLE definition_tags;
+DROP TABLE tags;
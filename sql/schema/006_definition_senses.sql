@@ -0,0 +1,16 @@
+-- +goose Up
+ALTER TABLE definitions DROP CONSTRAINT uc_definition;
+ALTER TABLE definitions ADD COLUMN etymology_number INT NOT NULL DEFAULT 0;
+ALTER TABLE definitions ADD COLUMN sense_index INT NOT NULL DEFAULT 0;
+ALTER TABLE definitions ADD CONSTRAINT uc_definition UNIQUE (word_id, pos_id, etymology_number, sense_index);
+
+-- +goose Down
+ALTER TABLE definitions DROP CONSTRAINT uc_definition;
+DELETE FROM definitions d WHERE EXISTS (
+    SELECT 1 FROM definitions o
+    WHERE o.word_id = d.word_id AND o.pos_id = d.pos_id
+    AND (o.etymology_number, o.sense_index) < (d.etymology_number, d.sense_index)
+);
+ALTER TABLE definitions DROP COLUMN sense_index;
+ALTER TABLE definitions DROP COLUMN etymology_number;
+ALTER TABLE definitions ADD CONSTRAINT uc_definition UNIQUE (word_id, pos_id);
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Forms and lemmas are stored as text rather than word IDs since either may
+-- appear later in a dump than the entry linking them, or not at all.
+CREATE TABLE word_forms(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    form TEXT NOT NULL,
+    lemma TEXT NOT NULL,
+    lang TEXT NOT NULL,
+    pos_id INT NOT NULL,
+    CONSTRAINT fk_pos_id
+    FOREIGN KEY (pos_id)
+    REFERENCES parts_of_speech(id)
+    ON DELETE CASCADE,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    CONSTRAINT uc_word_form
+    UNIQUE (form, lemma, lang, pos_id)
+);
+
+CREATE INDEX word_forms_form_idx ON word_forms (form, lang);
+CREATE INDEX word_forms_lemma_idx ON word_forms (lemma, lang);
+
+-- +goose Down
+DROP TABLE word_forms;
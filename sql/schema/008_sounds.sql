@@ -0,0 +1,35 @@
+-- +goose Up
+-- A pronunciation row holds one "sounds" item of a wiktionary entry, which
+-- usually gives only one of an IPA transcription, a rhyme or an audio file.
+CREATE TABLE pronunciations(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    word_id INT NOT NULL,
+    CONSTRAINT fk_word_id
+    FOREIGN KEY (word_id)
+    REFERENCES words(id)
+    ON DELETE CASCADE,
+    ipa TEXT NOT NULL DEFAULT '',
+    rhyme TEXT NOT NULL DEFAULT '',
+    audio TEXT NOT NULL DEFAULT '',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    CONSTRAINT uc_pronunciation
+    UNIQUE (word_id, ipa, rhyme, audio)
+);
+
+CREATE INDEX pronunciations_rhyme_idx ON pronunciations (rhyme);
+
+CREATE TABLE hyphenations(
+    word_id INT PRIMARY KEY,
+    CONSTRAINT fk_word_id
+    FOREIGN KEY (word_id)
+    REFERENCES words(id)
+    ON DELETE CASCADE,
+    hyphenation TEXT NOT NULL,
+    syllables INT NOT NULL
+);
+
+CREATE INDEX hyphenations_syllables_idx ON hyphenations (syllables);
+
+-- +goose Down
+DROP TABLE hyphenations;
+DROP TABLE pronunciations;
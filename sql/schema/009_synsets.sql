@@ -0,0 +1,54 @@
+-- +goose Up
+-- Synsets group the senses of words sharing a meaning, as in WordNet. Senses
+-- and relations refer to synsets and senses by their keys in the source rather
+-- than by IDs, since relation targets may appear later in a source than the
+-- senses linking to them, or be filtered out.
+CREATE TABLE synsets(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    synset_key TEXT UNIQUE NOT NULL,
+    pos_id INT NOT NULL,
+    CONSTRAINT fk_pos_id
+    FOREIGN KEY (pos_id)
+    REFERENCES parts_of_speech(id)
+    ON DELETE CASCADE,
+    definition TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE word_senses(
+    sense_key TEXT PRIMARY KEY,
+    word_id INT NOT NULL,
+    CONSTRAINT fk_word_id
+    FOREIGN KEY (word_id)
+    REFERENCES words(id)
+    ON DELETE CASCADE,
+    synset_key TEXT NOT NULL,
+    CONSTRAINT fk_synset_key
+    FOREIGN KEY (synset_key)
+    REFERENCES synsets(synset_key)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX word_senses_word_id_idx ON word_senses (word_id);
+CREATE INDEX word_senses_synset_key_idx ON word_senses (synset_key);
+
+-- Relations between synsets, e.g. hypernym or hyponym
+CREATE TABLE synset_relations(
+    synset_key TEXT NOT NULL,
+    target_key TEXT NOT NULL,
+    rel_type TEXT NOT NULL,
+    PRIMARY KEY (synset_key, target_key, rel_type)
+);
+
+-- Relations between senses of words, e.g. antonym
+CREATE TABLE sense_relations(
+    sense_key TEXT NOT NULL,
+    target_key TEXT NOT NULL,
+    rel_type TEXT NOT NULL,
+    PRIMARY KEY (sense_key, target_key, rel_type)
+);
+
+-- +goose Down
+DROP TABLE sense_relations;
+DROP TABLE synset_relations;
+DROP TABLE word_senses;
+DROP TABLE synsets;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- An import run records a makeDB import of a raw file so it can be resumed if
+-- interrupted. checkpoint is the number of records (e.g. lines) of the file
+-- read when the last batch of the run was committed, and file_hash the SHA-256
+-- of the file, to detect files changed since.
+CREATE TABLE import_runs(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    file_path TEXT NOT NULL,
+    file_hash TEXT NOT NULL,
+    source_format TEXT NOT NULL,
+    langs TEXT[] NOT NULL,
+    checkpoint BIGINT NOT NULL DEFAULT 0,
+    completed BOOLEAN NOT NULL DEFAULT false,
+    started_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX import_runs_file_path_idx ON import_runs (file_path);
+
+-- +goose Down
+DROP TABLE import_runs;
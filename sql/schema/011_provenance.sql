@@ -0,0 +1,65 @@
+-- +goose Up
+-- Sources are the formats raw files are imported from, with the license and
+-- home page of their content where it is the same for every file, as for
+-- Wiktionary. Import runs record the license of the file they read, along with
+-- its dump date and the filter config words were kept by, and the words and
+-- definitions they added link back to them.
+CREATE TABLE sources(
+    id INT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,
+    license TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO sources (name, license, url)
+VALUES ('wiktionary', 'CC BY-SA 4.0, GFDL', 'https://www.wiktionary.org/');
+INSERT INTO sources (name)
+SELECT DISTINCT source_format FROM import_runs
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE import_runs ADD COLUMN source_id INT;
+UPDATE import_runs SET source_id = sources.id
+FROM sources WHERE sources.name = import_runs.source_format;
+ALTER TABLE import_runs ALTER COLUMN source_id SET NOT NULL;
+ALTER TABLE import_runs ADD CONSTRAINT fk_source_id
+    FOREIGN KEY (source_id)
+    REFERENCES sources(id)
+    ON DELETE RESTRICT;
+ALTER TABLE import_runs DROP COLUMN source_format;
+
+ALTER TABLE import_runs ADD COLUMN file_name TEXT NOT NULL DEFAULT '';
+UPDATE import_runs SET file_name = regexp_replace(file_path, '^.*/', '');
+ALTER TABLE import_runs ADD COLUMN dump_date DATE;
+ALTER TABLE import_runs ADD COLUMN license TEXT NOT NULL DEFAULT '';
+ALTER TABLE import_runs ADD COLUMN filter_config JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE words ADD COLUMN import_run_id INT;
+ALTER TABLE words ADD CONSTRAINT fk_import_run_id
+    FOREIGN KEY (import_run_id)
+    REFERENCES import_runs(id)
+    ON DELETE SET NULL;
+CREATE INDEX words_import_run_id_idx ON words (import_run_id);
+
+ALTER TABLE definitions ADD COLUMN import_run_id INT;
+ALTER TABLE definitions ADD CONSTRAINT fk_import_run_id
+    FOREIGN KEY (import_run_id)
+    REFERENCES import_runs(id)
+    ON DELETE SET NULL;
+CREATE INDEX definitions_import_run_id_idx ON definitions (import_run_id);
+
+-- +goose Down
+ALTER TABLE definitions DROP COLUMN import_run_id;
+ALTER TABLE words DROP COLUMN import_run_id;
+
+ALTER TABLE import_runs DROP COLUMN filter_config;
+ALTER TABLE import_runs DROP COLUMN license;
+ALTER TABLE import_runs DROP COLUMN dump_date;
+ALTER TABLE import_runs DROP COLUMN file_name;
+
+ALTER TABLE import_runs ADD COLUMN source_format TEXT NOT NULL DEFAULT '';
+UPDATE import_runs SET source_format = sources.name
+FROM sources WHERE sources.id = import_runs.source_id;
+ALTER TABLE import_runs ALTER COLUMN source_format DROP DEFAULT;
+ALTER TABLE import_runs DROP COLUMN source_id;
+
+DROP TABLE sources;
@@ -0,0 +1,39 @@
+-- +goose Up
+-- Words link to every import run that kept them, not only to the one that
+-- added them, so their provenance covers each list they were imported into.
+CREATE TABLE word_import_runs(
+    word_id INT NOT NULL,
+    CONSTRAINT fk_word_id
+    FOREIGN KEY (word_id)
+    REFERENCES words(id)
+    ON DELETE CASCADE,
+    import_run_id INT NOT NULL,
+    CONSTRAINT fk_import_run_id
+    FOREIGN KEY (import_run_id)
+    REFERENCES import_runs(id)
+    ON DELETE CASCADE,
+    added BOOLEAN NOT NULL DEFAULT false,
+    PRIMARY KEY (word_id, import_run_id)
+);
+
+CREATE INDEX word_import_runs_import_run_id_idx ON word_import_runs (import_run_id);
+
+INSERT INTO word_import_runs (word_id, import_run_id, added)
+SELECT id, import_run_id, true FROM words WHERE import_run_id IS NOT NULL;
+INSERT INTO word_import_runs (word_id, import_run_id)
+SELECT DISTINCT word_id, import_run_id FROM definitions WHERE import_run_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE words DROP COLUMN import_run_id;
+
+-- +goose Down
+ALTER TABLE words ADD COLUMN import_run_id INT;
+ALTER TABLE words ADD CONSTRAINT fk_import_run_id
+    FOREIGN KEY (import_run_id)
+    REFERENCES import_runs(id)
+    ON DELETE SET NULL;
+CREATE INDEX words_import_run_id_idx ON words (import_run_id);
+UPDATE words SET import_run_id = word_import_runs.import_run_id
+FROM word_import_runs WHERE word_import_runs.word_id = words.id AND word_import_runs.added;
+
+DROP TABLE word_import_runs;
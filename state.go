@@ -1,11 +1,24 @@
 package main
 
 import (
+	"database/sql"
+	"fmt"
+
 	"github.com/pbojar/dictextract/internal/config"
 	"github.com/pbojar/dictextract/internal/database"
 )
 
 type state struct {
-	db  *database.Queries
-	cfg *config.Config
+	db   *database.Queries // nil if no database is configured
+	conn *sql.DB           // Connection db runs on, for transactions
+	cfg  *config.Config
+}
+
+// requireDB returns an error if no database is configured, for commands that
+// cannot run without one.
+func (s *state) requireDB() error {
+	if s.db == nil {
+		return fmt.Errorf("error: no database configured, set db_url in the config")
+	}
+	return nil
 }